@@ -4,7 +4,6 @@ import (
 	"flag"
 	"fmt"
 	"lynn/lynn"
-	"lynn/lynn/parser"
 	"os"
 	"path/filepath"
 )
@@ -12,9 +11,12 @@ import (
 func main() {
     // Configure CLI flags
     cmd := filepath.Base(os.Args[0])
-    var name, lang string; var log bool
+    var name, lang, mode string; var log, glr, strict bool
     flag.StringVar(&name, "o", "parser", "Output Go package name")
     flag.StringVar(&lang, "l", "go", "Output program language (\"go\" or \"ts\")")
+    flag.StringVar(&mode, "t", "lalr", "Parse table construction mode (\"lalr\", \"lr1\", or \"minimal\")")
+    flag.BoolVar(&glr, "g", false, "Generate GLR parser that keeps conflicting actions (Go only)")
+    flag.BoolVar(&strict, "w", false, "Treat grammar warnings as errors")
     flag.BoolVar(&log, "a", false, "Log syntax tree and augmented grammar")
     flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <path>\n", cmd)
@@ -26,38 +28,46 @@ func main() {
     args := flag.Args()
     if len(args) != 1 { flag.Usage(); return }
     path := args[0]
-    f, e := os.Open(path)
-    if e != nil { panic(e) }
-    defer f.Close()
+    lynn.WarningsAsErrors = strict
+    // Validate flags before any work is done
+    var tableMode lynn.TableMode
+    switch mode {
+    case "lalr":    tableMode = lynn.LALR_TABLE
+    case "lr1":     tableMode = lynn.LR1_TABLE
+    case "minimal": tableMode = lynn.MINIMAL_LR1_TABLE
+    default:
+        fmt.Fprintf(os.Stderr, "Invalid parse table construction mode %q (expected \"lalr\", \"lr1\", or \"minimal\")\n", mode)
+        Fail(); return
+    }
+    if lang != "go" && lang != "ts" {
+        fmt.Fprintf(os.Stderr, "Invalid output program language %q (expected \"go\" or \"ts\")\n", lang)
+        Fail(); return
+    }
+    if glr && lang != "go" {
+        fmt.Fprintln(os.Stderr, "GLR parsers can only be generated in Go")
+        Fail(); return
+    }
 
-    // Parse input grammar file and generate abstract syntax tree
+    // Parse input grammar file and its imports and generate abstract syntax tree
     fmt.Println("== Parsing grammar definition file... ==")
-    err := false
-    lexer := parser.NewLexer(f, func (stream *parser.InputStream, char rune, location parser.Location) {
-        parser.DEFAULT_LEXER_HANDLER(stream, char, location)
-        err = true
-    })
-    tree := parser.NewParser(lexer, func (token parser.Token) {
-        parser.DEFAULT_PARSER_HANDLER(token)
-        err = true
-    }).Parse()
-    if err { Fail(); return }
-    fmt.Println("[1/8] Generated parse tree")
-
-    ast := lynn.NewParseTreeVisitor().VisitGrammar(tree).(*lynn.GrammarNode)
+    loader := lynn.NewGrammarLoader()
+    ast := loader.Load(path)
     if lynn.Panic() { Fail(); return }
+    fmt.Println("[1/8] Generated parse tree")
     fmt.Println("[2/8] Created abstract syntax tree")
     if log { fmt.Println(ast) }
 
     // Generate lexer data and compile to program
     fmt.Println()
     fmt.Println("== Generating lexer data... ==")
-    generator := lynn.NewLexerGenerator()
+    generator := lynn.NewLexerGenerator(loader)
     nfa, ranges := generator.GenerateNFA(ast)
     if lynn.Panic() { Fail(); return }
     fmt.Println("[3/8] Generated non-deterministic finite automata")
 
-    dfa := generator.NFAtoDFA(nfa, ranges)
+    dfa := make([]lynn.LDFA, len(nfa))
+    for i, n := range nfa { dfa[i] = generator.NFAtoDFA(n, ranges) }
+    if lynn.Panic() { Fail(); return }
     fmt.Println("[4/8] Generated deterministic finite automata")
 
     // Generate parser data and compile to program
@@ -65,12 +75,18 @@ func main() {
     fmt.Println("== Generating parser data... ==")
     grammar, maps := lynn.NewGrammarGenerator().GenerateCFG(ast)
     if lynn.Panic() { Fail(); return }
+    lynn.NewGrammarAnalyzer(loader).Analyze(grammar, ast)
+    if lynn.Panic() { Fail(); return }
     fmt.Println("[5/8] Generated context-free grammar")
     if log { grammar.PrintGrammar() }
 
-    table := lynn.NewLALRParserGenerator().Generate(grammar)
+    table := lynn.NewLALRParserGenerator(tableMode, glr).Generate(grammar)
     if lynn.Panic() { Fail(); return }
-    fmt.Println("[6/8] Generated LALR(1) parse table")
+    switch tableMode {
+    case lynn.LALR_TABLE: fmt.Println("[6/8] Generated LALR(1) parse table")
+    case lynn.LR1_TABLE:  fmt.Println("[6/8] Generated canonical LR(1) parse table")
+    default:              fmt.Println("[6/8] Generated minimal LR(1) parse table")
+    }
 
     fmt.Println()
     fmt.Println("== Compiling generated programs... ==")
@@ -79,14 +95,15 @@ func main() {
         lynn.CompileLexerGo(name, dfa, ranges, ast)
         fmt.Println("[7/8] Compiled lexer program")
         lynn.CompileParserGo(name, table, maps, ast)
+        if lynn.Panic() { Fail(); return }
         fmt.Println("[8/8] Compiled parser program")
     case "ts":
         lynn.CompileLexerTS(dfa, ranges, ast)
         fmt.Println("[7/8] Compiled lexer program")
         lynn.CompileParserTS(table, maps, ast)
         fmt.Println("[8/8] Compiled parser program")
-    default: Fail()
     }
 }
 
-func Fail() { fmt.Fprintln(os.Stderr, "Error occurred: Failed to generate programs") }
+// Reports that generation failed and exits with a non-zero status.
+func Fail() { fmt.Fprintln(os.Stderr, "Error occurred: Failed to generate programs"); os.Exit(1) }
@@ -0,0 +1,148 @@
+package lynn
+
+import "fmt"
+
+// Grammar analyzer struct. Reports rules and tokens of a grammar that do not contribute to the language it describes.
+type GrammarAnalyzer struct {
+    loader  *GrammarLoader // Loader of the grammar, used to find the files of declarations
+    grammar *Grammar
+    rules   map[NonTerminal][]*Production // Productions of each non-terminal
+}
+
+// Returns a new grammar analyzer struct, which reports the locations of warnings in the files loaded by the given loader.
+func NewGrammarAnalyzer(loader *GrammarLoader) *GrammarAnalyzer { return &GrammarAnalyzer { loader: loader } }
+// Reports warnings for rules that are not reachable from a start rule, tokens that are not used by any rule,
+// inline rules that are not used by any reachable rule, and rules that cannot derive any sequence of tokens.
+func (a *GrammarAnalyzer) Analyze(grammar *Grammar, ast *GrammarNode) {
+    a.grammar = grammar
+    a.rules = make(map[NonTerminal][]*Production)
+    for _, p := range grammar.Productions { a.rules[p.Left] = append(a.rules[p.Left], p) }
+    // Derived non-terminals are attributed to the rule or template they were derived from
+    root := func (t NonTerminal) NonTerminal {
+        if parent, ok := grammar.Parents[t]; ok { return parent }
+        return t
+    }
+    reachable, productive := a.findReachable(), a.findProductive()
+    used := make(map[NonTerminal]struct{})
+    for t := range reachable { used[root(t)] = struct{}{} }
+    // Rules that cannot derive any sequence of tokens, or contain an expression that cannot
+    unproductive := make(map[NonTerminal]struct{})
+    for _, t := range grammar.NonTerminals {
+        if _, ok := productive[t]; !ok { unproductive[root(t)] = struct{}{} }
+    }
+    // Inline rules are substituted where they are referenced, so they are used if a used rule or inline rule refers to them
+    inline := make(map[string][]*RuleNode)
+    for _, rule := range ast.Rules {
+        if rule.Inline { inline[rule.Identifier.Name] = append(inline[rule.Identifier.Name], rule) }
+    }
+    work := make([]AST, 0)
+    for _, rule := range ast.Rules {
+        if _, ok := used[NonTerminal(rule.Identifier.Name)]; ok && !rule.Inline { work = append(work, rule.Expression) }
+    }
+    for len(work) > 0 {
+        expression := work[len(work) - 1]; work = work[:len(work) - 1]
+        findReferences(expression, func (id *IdentifierNode) {
+            t := NonTerminal(id.Name)
+            if _, ok := used[t]; ok { return }
+            if rules, ok := inline[id.Name]; ok {
+                used[t] = struct{}{}
+                for _, rule := range rules { work = append(work, rule.Expression) }
+            }
+        })
+    }
+    reported := make(map[string]struct{})
+    for _, rule := range ast.Rules {
+        id := rule.Identifier; t := NonTerminal(id.Name)
+        if _, ok := reported[id.Name]; ok { continue }
+        reported[id.Name] = struct{}{}
+        if _, ok := used[t]; !ok && rule.Inline {
+            Warning(fmt.Sprintf("Inline rule \"%s\" is not used by any reachable rule - %s", id.Name, a.loader.location(id)))
+            continue
+        } else if !ok {
+            Warning(fmt.Sprintf("Rule \"%s\" is not reachable from a start rule - %s", id.Name, a.loader.location(id)))
+            continue
+        }
+        if _, ok := unproductive[t]; !ok || rule.Inline { continue }
+        if _, ok := productive[t]; !ok && len(rule.Parameters) == 0 {
+            Warning(fmt.Sprintf("Rule \"%s\" cannot derive any sequence of tokens - %s", id.Name, a.loader.location(id)))
+        } else {
+            Warning(fmt.Sprintf("Rule \"%s\" contains an expression that cannot derive any sequence of tokens - %s",
+                id.Name, a.loader.location(id)))
+        }
+    }
+    // Tokens that are passed to the parser but never appear in a production
+    terminals := make(map[Terminal]struct{})
+    for _, p := range grammar.Productions {
+        for _, s := range p.Right {
+            if t, ok := s.(Terminal); ok { terminals[t] = struct{}{} }
+        }
+    }
+    for _, token := range ast.Tokens {
+        if token.Skip || token.Hidden { continue }
+        id := token.Identifier; t := Terminal(id.Name)
+        if t == EOF_TERMINAL { continue }
+        if _, ok := terminals[t]; !ok {
+            Warning(fmt.Sprintf("Token \"%s\" is not used by any rule - %s", id.Name, a.loader.location(id)))
+        }
+    }
+}
+
+// Calls the given function for each identifier referred to by an expression, including the arguments of templates.
+func findReferences(expression AST, visit func (id *IdentifierNode)) {
+    switch node := expression.(type) {
+    case *IdentifierNode:  visit(node)
+    case *OptionNode:      findReferences(node.Expression, visit)
+    case *RepeatNode:      findReferences(node.Expression, visit)
+    case *RepeatOneNode:   findReferences(node.Expression, visit)
+    case *RepeatRangeNode: findReferences(node.Expression, visit)
+    case *SeparatedNode:   findReferences(node.Expression, visit); findReferences(node.Separator, visit)
+    case *LabelNode:       findReferences(node.Expression, visit)
+    case *AliasNode:       findReferences(node.Expression, visit)
+    case *DropNode:        findReferences(node.Expression, visit)
+    case *HoistNode:       findReferences(node.Expression, visit)
+    case *ConcatNode:      findReferences(node.A, visit); findReferences(node.B, visit)
+    case *UnionNode:       findReferences(node.A, visit); findReferences(node.B, visit)
+    case *TemplateNode:
+        visit(node.Identifier)
+        for _, a := range node.Arguments { findReferences(a, visit) }
+    }
+}
+
+// Finds all non-terminals that may be reached from the start non-terminal or an entry non-terminal.
+func (a *GrammarAnalyzer) findReachable() map[NonTerminal]struct{} {
+    reachable := map[NonTerminal]struct{} { a.grammar.Start: {} }
+    work := []NonTerminal { a.grammar.Start }
+    for _, t := range a.grammar.Entries {
+        if _, ok := reachable[t]; !ok { reachable[t] = struct{}{}; work = append(work, t) }
+    }
+    // Iterative implementation, process non-terminals in work list until no new non-terminals are reached
+    for len(work) > 0 {
+        t := work[0]; work = work[1:]
+        for _, p := range a.rules[t] {
+            for _, s := range p.Right {
+                n, ok := s.(NonTerminal); if !ok { continue }
+                if _, ok := reachable[n]; !ok { reachable[n] = struct{}{}; work = append(work, n) }
+            }
+        }
+    }
+    return reachable
+}
+
+// Finds all non-terminals that derive at least one sequence of terminals.
+func (a *GrammarAnalyzer) findProductive() map[NonTerminal]struct{} {
+    productive := make(map[NonTerminal]struct{})
+    // Iterative implementation, a non-terminal is productive if all symbols of one of its productions are productive
+    for changed := true; changed; {
+        changed = false
+        for _, p := range a.grammar.Productions {
+            if _, ok := productive[p.Left]; ok { continue }
+            derives := true
+            for _, s := range p.Right {
+                n, ok := s.(NonTerminal); if !ok { continue }
+                if _, ok := productive[n]; !ok { derives = false; break }
+            }
+            if derives { productive[p.Left] = struct{}{}; changed = true }
+        }
+    }
+    return productive
+}
@@ -4,6 +4,8 @@ import (
 	"cmp"
 	"fmt"
 	"lynn/lynn/parser"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,33 +20,59 @@ type GrammarNode struct {
     Precedence []*PrecedenceNode
     Tokens     []*TokenNode
     Fragments  []*FragmentNode
+    Modes      []*ModeNode
+    Entries    []*EntryNode
+    Options    []*GrammarOptionNode
 }
 
 // Node representing a grammar rule. Specifies the rule's identifier and regular expression.
+// Rules with parameters are templates, which are instantiated where they are used.
+// Inline rules are substituted where they are used and do not generate parse tree nodes.
 type RuleNode struct {
     Identifier *IdentifierNode
+    Parameters []*IdentifierNode
     Expression AST
+    Inline     bool
     Start, End parser.Location
 }
 
-// Associativity type enum. Either NO_ASSOC, LEFT_ASSOC, or RIGHT_ASSOC.
+// Associativity type enum. Either NO_ASSOC, LEFT_ASSOC, RIGHT_ASSOC, or NON_ASSOC.
 type AssociativityType uint
-const (NO_ASSOC AssociativityType = iota; LEFT_ASSOC; RIGHT_ASSOC)
+const (NO_ASSOC AssociativityType = iota; LEFT_ASSOC; RIGHT_ASSOC; NON_ASSOC)
 // Node representing a precedence statement. Specifies the a precedence level and its associativity.
+// Tokens listed in the statement are assigned the precedence level, which is used to resolve parse table conflicts.
 type PrecedenceNode struct {
     Identifier    *IdentifierNode
     Associativity AssociativityType
+    Tokens        []AST
     Start, End    parser.Location
 }
 
 // Node representing a token rule. Specifies the token's identifier and regular expression.
+// Also specifies the lexer mode the token belongs to and the mode action performed after the token is matched.
+// Hidden tokens are not passed to the parser, but are attached to the next token as trivia.
 type TokenNode struct {
     Identifier *IdentifierNode
     Expression AST
     Skip       bool
+    Hidden     bool
+    NoCase     bool
+    Mode       string
+    Action     *ModeActionNode
     Start, End parser.Location
 }
 
+// Node representing a start statement. Specifies an additional rule that the parser may start parsing from.
+type EntryNode struct { Identifier *IdentifierNode; Start, End parser.Location }
+
+// Node representing an option statement. Specifies an option that applies to the entire grammar.
+type GrammarOptionNode struct { Identifier *IdentifierNode; Start, End parser.Location }
+// Option that omits string literal tokens that are not aliased from the parse tree.
+const DROP_LITERALS_OPTION = "dropLiterals"
+
+// Node representing an import statement. Specifies the path of the imported grammar definition file.
+type ImportNode struct { Path string; Start, End parser.Location }
+
 // Node representing a rule fragment. Specifies the fragment's identifier and regular expression.
 // Fragments are used to repeat regular expressions in token rules.
 type FragmentNode struct {
@@ -53,12 +81,51 @@ type FragmentNode struct {
     Start, End parser.Location
 }
 
+// Name of the lexer mode that tokens declared before any mode statement belong to.
+const DEFAULT_MODE string = "DEFAULT"
+// Node representing a lexer mode statement. All subsequent token rules belong to this mode.
+type ModeNode struct { Identifier *IdentifierNode; Start, End parser.Location }
+
+// Node representing a skip action. Discards the token after it is matched.
+type SkipNode struct { Start, End parser.Location }
+// Name of the only channel tokens may be assigned to using a channel action.
+const HIDDEN_CHANNEL string = "HIDDEN"
+// Node representing a channel action. Hides the token from the parser.
+type ChannelNode struct { Identifier *IdentifierNode; Start, End parser.Location }
+// Node representing a nocase action. Matches all case-folded equivalents of the token's characters.
+type NoCaseNode struct { Start, End parser.Location }
+// Mode action type enum. Either PUSH_MODE, POP_MODE, or SET_MODE.
+type ModeActionType uint
+const (PUSH_MODE ModeActionType = iota; POP_MODE; SET_MODE)
+// Node representing a mode action. Modifies the lexer's mode stack after the token is matched.
+type ModeActionNode struct {
+    Type       ModeActionType
+    Mode       *IdentifierNode // Target mode, nil for pop actions
+    Start, End parser.Location
+}
+
 // Node representing an option quantifier. Allows zero or one occurrence of the given regular expression.
 type OptionNode struct { Expression AST; Start, End parser.Location }
 // Node representing an repeat quantifier. Allows zero or more occurrences of the given regular expression.
 type RepeatNode struct { Expression AST; Start, End parser.Location }
 // Node representing an repeat one or more quantifier. Allows one or more occurrences of the given regular expression.
 type RepeatOneNode struct { Expression AST; Start, End parser.Location }
+// Node representing a bounded repeat quantifier. Allows between Min and Max occurrences of the given regular expression.
+// Max is UNBOUNDED if no upper bound is given.
+type RepeatRangeNode struct {
+    Expression AST
+    Min, Max   int
+    Start, End parser.Location
+}
+const UNBOUNDED int = -1
+// Node representing a separated list. Allows occurrences of the given regular expression separated by the separator expression.
+// Zero occurrences are allowed unless NonEmpty is set.
+type SeparatedNode struct {
+    Expression AST
+    Separator  AST
+    NonEmpty   bool
+    Start, End parser.Location
+}
 
 // Node representing a rule case label. Specifies the callback identifier and associativity for the disambiguation process.
 type LabelNode struct {
@@ -67,6 +134,10 @@ type LabelNode struct {
     Precedence *IdentifierNode
     Start, End parser.Location
 }
+// Node representing a dropped item. The item is omitted from the parse tree node generated by the production.
+type DropNode struct { Expression AST; Start, End parser.Location }
+// Node representing a hoisted item. The item replaces the parse tree node generated by the production.
+type HoistNode struct { Expression AST; Start, End parser.Location }
 // Node representing an alias. Specifies the alias identifier and the corresponding expression.
 type AliasNode struct {
     Identifier *IdentifierNode
@@ -85,104 +156,265 @@ type UnionNode struct {
     Start, End parser.Location
 }
 
+// Node representing a class difference operation. Matches characters in the first set that are not in the second set.
+type DifferenceNode struct {
+    A, B       AST
+    Start, End parser.Location
+}
+// Node representing a class intersection operation. Matches characters that are in both sets.
+type IntersectionNode struct {
+    A, B       AST
+    Start, End parser.Location
+}
+
+// Node representing a rule template instantiation. Specifies the template's identifier and the arguments for its parameters.
+type TemplateNode struct {
+    Identifier *IdentifierNode
+    Arguments  []AST
+    Start, End parser.Location
+}
+
 // Node representing an identifier literal.
 type IdentifierNode struct { Name string; Start, End parser.Location }
-// Node representing a string literal.
-type StringNode struct { Chars []rune; Start, End parser.Location }
+// Node representing a string literal. Insensitive strings match all case-folded equivalents of their characters.
+type StringNode struct {
+    Chars       []rune
+    Insensitive bool
+    Start, End  parser.Location
+}
 // Node representing a class literal.
 type ClassNode struct { Ranges []parser.Range; Start, End parser.Location }
 // Node representing an error literal.
 type ErrorNode struct { Start, End parser.Location }
 
 // Parse tree visitor struct. Converts parse tree to abstract syntax tree (AST).
-type ParseTreeVisitor struct { }
+// Import statements are resolved relative to the visited file using the given grammar loader.
+type ParseTreeVisitor struct { loader *GrammarLoader; path string }
 // Returns new parse tree visitor struct.
-func NewParseTreeVisitor() ParseTreeVisitor { return ParseTreeVisitor { } }
+func NewParseTreeVisitor(loader *GrammarLoader, path string) ParseTreeVisitor { return ParseTreeVisitor { loader, path } }
 
-func (v ParseTreeVisitor) VisitGrammar(node *parser.ParseTreeNode) AST {
+func (v ParseTreeVisitor) VisitGrammar(node parser.GrammarNode) AST {
     rules, precedence, tokens, fragments := make([]*RuleNode, 0), make([]*PrecedenceNode, 0), make([]*TokenNode, 0), make([]*FragmentNode, 0)
+    // Tokens declared before any mode statement belong to the default mode
+    modes := []*ModeNode { { Identifier: &IdentifierNode { Name: DEFAULT_MODE } } }
+    entries, options, mode, imported := make([]*EntryNode, 0), make([]*GrammarOptionNode, 0), DEFAULT_MODE, make([]*RuleNode, 0)
     for _, node := range node.Stmt().(*parser.ParseTreeNode).Children {
         switch rule := parser.VisitNode(v, node.(*parser.ParseTreeNode)).(type) {
-        case *RuleNode: rules = append(rules, rule)
-        case *PrecedenceNode: precedence = append(precedence, rule)
-        case *TokenNode: tokens = append(tokens, rule)
-        case *FragmentNode: fragments = append(fragments, rule)
+        case *RuleNode:
+            v.loader.declare(rule.Identifier, v.path)
+            rules = append(rules, rule)
+        case *PrecedenceNode:
+            v.loader.declare(rule.Identifier, v.path)
+            precedence = append(precedence, rule)
+        case *TokenNode:
+            v.loader.declare(rule.Identifier, v.path)
+            rule.Mode = mode
+            tokens = append(tokens, rule)
+        case *FragmentNode:
+            v.loader.declare(rule.Identifier, v.path)
+            fragments = append(fragments, rule)
+        case *ModeNode:
+            v.loader.declare(rule.Identifier, v.path)
+            mode = rule.Identifier.Name
+            modes = append(modes, rule)
+        case *EntryNode: entries = append(entries, rule)
+        case *GrammarOptionNode: options = append(options, rule)
+        case *ImportNode:
+            // Declarations of imported grammar are inserted in place of the import statement
+            // Imported rules are listed after all rules of this grammar so the start rule is unaffected
+            // Files that fail to load or were already loaded return nil
+            grammar := v.loader.load(filepath.Join(filepath.Dir(v.path), rule.Path), v.path, rule.Start)
+            if grammar == nil { continue }
+            precedence = append(precedence, grammar.Precedence...)
+            tokens = append(tokens, grammar.Tokens...)
+            fragments = append(fragments, grammar.Fragments...)
+            modes = append(modes, grammar.Modes[1:]...) // Default mode is already listed
+            entries = append(entries, grammar.Entries...)
+            options = append(options, grammar.Options...)
+            imported = append(imported, grammar.Rules...)
         }
     }
-    if len(rules) == 0 || len(tokens) == 0 {
-        location := node.Start
-        Error(fmt.Sprintf("Grammar definition must contain at least one rule and token - %d:%d", location.Line, location.Col))
-    }
-    return &GrammarNode { rules, precedence, tokens, fragments }
+    return &GrammarNode { append(rules, imported...), precedence, tokens, fragments, modes, entries, options }
 }
 
-func (v ParseTreeVisitor) VisitStmt(node *parser.ParseTreeNode) AST { panic("Invalid statement") }
-func (v ParseTreeVisitor) VisitRuleStmt(node *parser.ParseTreeNode) AST {
-    id := node.IDENTIFIER().(parser.Token)
+func (v ParseTreeVisitor) VisitStmt(node parser.StmtNode) AST { panic("Invalid statement") }
+func (v ParseTreeVisitor) VisitRuleStmt(node parser.RuleStmtNode) AST {
+    id := node.Id()
     identifier := &IdentifierNode { id.Value, id.Start, id.End }
-    return &RuleNode { identifier, parser.VisitNode(v, node.Expr()), node.Start, node.End }
+    var parameters []*IdentifierNode
+    if p, ok := node.P().(*parser.ParseTreeNode); ok {
+        // Collect first parameter and all subsequent comma-separated parameters
+        tokens := []parser.Token { p.Id().(parser.Token) }
+        for _, n := range p.Children[2].(*parser.ParseTreeNode).Children {
+            tokens = append(tokens, n.(*parser.ParseTreeNode).Id().(parser.Token))
+        }
+        parameters = make([]*IdentifierNode, 0, len(tokens))
+        for _, t := range tokens {
+            if slices.ContainsFunc(parameters, func (n *IdentifierNode) bool { return n.Name == t.Value }) {
+                Error(fmt.Sprintf("Parameter \"%s\" is already defined - %d:%d", t.Value, t.Start.Line, t.Start.Col))
+                continue
+            }
+            parameters = append(parameters, &IdentifierNode { t.Value, t.Start, t.End })
+        }
+    }
+    inline := node.I() != nil
+    return &RuleNode { identifier, parameters, parser.VisitNode(v, node.Expr()), inline, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitPrecedenceStmt(node *parser.ParseTreeNode) AST {
-    id := node.IDENTIFIER().(parser.Token)
+func (v ParseTreeVisitor) VisitPrecedenceStmt(node parser.PrecedenceStmtNode) AST {
+    id := node.Id()
     identifier := &IdentifierNode { id.Value, id.Start, id.End }
-    var assoc AssociativityType
+    var assoc AssociativityType; tokens := make([]AST, 0)
     if value, ok := node.V().(*parser.ParseTreeNode); ok {
         switch value.A().(parser.Token).Type {
-        case parser.LEFT:  assoc = LEFT_ASSOC
-        case parser.RIGHT: assoc = RIGHT_ASSOC
+        case parser.LEFT:     assoc = LEFT_ASSOC
+        case parser.RIGHT:    assoc = RIGHT_ASSOC
+        case parser.NONASSOC: assoc = NON_ASSOC
         default: panic("Invalid associativity type")
         }
+        // Tokens may be referred to by identifier or by the string they match
+        for _, n := range value.T().(*parser.ParseTreeNode).Children {
+            t := n.(parser.Token)
+            switch t.Type {
+            case parser.STRING:
+                value := t.Value[1:len(t.Value) - 1] // Remove quotation marks
+                tokens = append(tokens, &StringNode { reduceString([]rune(value)), false, t.Start, t.End })
+            default: tokens = append(tokens, &IdentifierNode { t.Value, t.Start, t.End })
+            }
+        }
     } else { assoc = NO_ASSOC }
-    return &PrecedenceNode { identifier, assoc, node.Start, node.End }
+    return &PrecedenceNode { identifier, assoc, tokens, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitTokenStmt(node *parser.ParseTreeNode) AST {
-    id := node.IDENTIFIER().(parser.Token)
+func (v ParseTreeVisitor) VisitTokenStmt(node parser.TokenStmtNode) AST {
+    id := node.Id()
     identifier := &IdentifierNode { id.Value, id.Start, id.End }
-    var expr AST; var skip bool
+    var expr AST; var skip, hidden, nocase bool; var action *ModeActionNode
     if value, ok := node.V().(*parser.ParseTreeNode); ok {
         expr = parser.VisitNode(v, value.Expr())
-        skip = value.S() != nil
+        if a, ok := value.A().(*parser.ParseTreeNode); ok {
+            // Collect first action and all subsequent comma-separated actions
+            actions := []AST { parser.VisitNode(v, a.Action()) }
+            for _, n := range a.Children[2].(*parser.ParseTreeNode).Children {
+                actions = append(actions, parser.VisitNode(v, n.(*parser.ParseTreeNode).Action()))
+            }
+            for _, n := range actions {
+                switch n := n.(type) {
+                case *SkipNode:   skip = true
+                case *ChannelNode:
+                    if id := n.Identifier; id.Name != HIDDEN_CHANNEL {
+                        Error(fmt.Sprintf("Channel \"%s\" is not defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+                    }
+                    hidden = true
+                case *NoCaseNode: nocase = true
+                case *ModeActionNode:
+                    // Only one mode action may be associated with a token
+                    if action != nil {
+                        Error(fmt.Sprintf("Token \"%s\" cannot have multiple mode actions - %d:%d", id.Value, n.Start.Line, n.Start.Col))
+                    }
+                    action = n
+                }
+            }
+            if skip && hidden {
+                Error(fmt.Sprintf("Token \"%s\" cannot be both skipped and hidden - %d:%d", id.Value, a.Start.Line, a.Start.Col))
+            }
+        }
     }
-    return &TokenNode { identifier, expr, skip, node.Start, node.End }
+    return &TokenNode { identifier, expr, skip, hidden, nocase, "", action, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitFragmentStmt(node *parser.ParseTreeNode) AST {
-    id := node.IDENTIFIER().(parser.Token)
+func (v ParseTreeVisitor) VisitFragmentStmt(node parser.FragmentStmtNode) AST {
+    id := node.Id()
     identifier := &IdentifierNode { id.Value, id.Start, id.End }
     return &FragmentNode { identifier, parser.VisitNode(v, node.Expr()), node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitUnionExpr(node *parser.ParseTreeNode) AST {
+func (v ParseTreeVisitor) VisitModeStmt(node parser.ModeStmtNode) AST {
+    id := node.Id()
+    return &ModeNode { &IdentifierNode { id.Value, id.Start, id.End }, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitStartStmt(node parser.StartStmtNode) AST {
+    id := node.Id()
+    return &EntryNode { &IdentifierNode { id.Value, id.Start, id.End }, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitOptionStmt(node parser.OptionStmtNode) AST {
+    id := node.Id()
+    if id.Value != DROP_LITERALS_OPTION {
+        Error(fmt.Sprintf("Option \"%s\" is not defined - %d:%d", id.Value, id.Start.Line, id.Start.Col))
+    }
+    return &GrammarOptionNode { &IdentifierNode { id.Value, id.Start, id.End }, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitImportStmt(node parser.ImportStmtNode) AST {
+    str := node.STRING()
+    value := str.Value[1:len(str.Value) - 1] // Remove quotation marks
+    return &ImportNode { string(reduceString([]rune(value))), node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitSkipAction(node parser.SkipActionNode) AST { return &SkipNode { node.Start, node.End } }
+func (v ParseTreeVisitor) VisitPushModeAction(node parser.PushModeActionNode) AST {
+    id := node.Id()
+    return &ModeActionNode { PUSH_MODE, &IdentifierNode { id.Value, id.Start, id.End }, node.Start, node.End }
+}
+func (v ParseTreeVisitor) VisitChannelAction(node parser.ChannelActionNode) AST {
+    id := node.Id()
+    return &ChannelNode { &IdentifierNode { id.Value, id.Start, id.End }, node.Start, node.End }
+}
+func (v ParseTreeVisitor) VisitNocaseAction(node parser.NocaseActionNode) AST { return &NoCaseNode { node.Start, node.End } }
+func (v ParseTreeVisitor) VisitPopModeAction(node parser.PopModeActionNode) AST { return &ModeActionNode { POP_MODE, nil, node.Start, node.End } }
+func (v ParseTreeVisitor) VisitModeAction(node parser.ModeActionNode) AST {
+    id := node.Id()
+    return &ModeActionNode { SET_MODE, &IdentifierNode { id.Value, id.Start, id.End }, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitUnionExpr(node parser.UnionExprNode) AST {
     left, right := parser.VisitNode(v, node.L()), parser.VisitNode(v, node.R())
     return &UnionNode { left, right, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitLabelExpr(node *parser.ParseTreeNode) AST {
-    id := node.IDENTIFIER().(parser.Token)
+func (v ParseTreeVisitor) VisitLabelExpr(node parser.LabelExprNode) AST {
+    id := node.Id()
     identifier := &IdentifierNode { id.Value, id.Start, id.End }
     var precedence *IdentifierNode
     if t, ok := node.P().(*parser.ParseTreeNode); ok {
-        n := t.IDENTIFIER().(parser.Token)
+        n := t.Id().(parser.Token)
         precedence = &IdentifierNode { n.Value, n.Start, n.End }
     }
     return &LabelNode { parser.VisitNode(v, node.Expr()), identifier, precedence, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitConcatExpr(node *parser.ParseTreeNode) AST {
+func (v ParseTreeVisitor) VisitConcatExpr(node parser.ConcatExprNode) AST {
     left, right := parser.VisitNode(v, node.L()), parser.VisitNode(v, node.R())
     return &ConcatNode { left, right, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitAliasExpr(node *parser.ParseTreeNode) AST {
-    id := node.IDENTIFIER().(parser.Token)
+func (v ParseTreeVisitor) VisitDifferenceExpr(node parser.DifferenceExprNode) AST {
+    left, right := parser.VisitNode(v, node.L()), parser.VisitNode(v, node.R())
+    return &DifferenceNode { left, right, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitIntersectionExpr(node parser.IntersectionExprNode) AST {
+    left, right := parser.VisitNode(v, node.L()), parser.VisitNode(v, node.R())
+    return &IntersectionNode { left, right, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitAliasExpr(node parser.AliasExprNode) AST {
+    id := node.Id()
     identifier := &IdentifierNode { id.Value, id.Start, id.End }
     return &AliasNode { identifier, parser.VisitNode(v, node.Expr()), node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitQuantifierExpr(node *parser.ParseTreeNode) AST {
-    switch node.Op().(parser.Token).Type {
+func (v ParseTreeVisitor) VisitDropExpr(node parser.DropExprNode) AST {
+    return &DropNode { parser.VisitNode(v, node.Expr()), node.Start, node.End }
+}
+func (v ParseTreeVisitor) VisitHoistExpr(node parser.HoistExprNode) AST {
+    return &HoistNode { parser.VisitNode(v, node.Expr()), node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitQuantifierExpr(node parser.QuantifierExprNode) AST {
+    switch node.Op().Type {
     case parser.QUESTION: return &OptionNode    { parser.VisitNode(v, node.Expr()), node.Start, node.End }
     case parser.STAR:     return &RepeatNode    { parser.VisitNode(v, node.Expr()), node.Start, node.End }
     case parser.PLUS:     return &RepeatOneNode { parser.VisitNode(v, node.Expr()), node.Start, node.End }
@@ -190,37 +422,86 @@ func (v ParseTreeVisitor) VisitQuantifierExpr(node *parser.ParseTreeNode) AST {
     }
 }
 
-func (v ParseTreeVisitor) VisitGroupExpr(node *parser.ParseTreeNode) AST { return parser.VisitNode(v, node.Expr()) }
-func (v ParseTreeVisitor) VisitIdentifierExpr(node *parser.ParseTreeNode) AST {
-    return &IdentifierNode { node.IDENTIFIER().(parser.Token).Value, node.Start, node.End }
+func (v ParseTreeVisitor) VisitSeparatedExpr(node parser.SeparatedExprNode) AST {
+    left, right := parser.VisitNode(v, node.L()), parser.VisitNode(v, node.R())
+    switch node.Op().Type {
+    case parser.PERCENT:  return &SeparatedNode { left, right, false, node.Start, node.End }
+    case parser.PCT_PLUS: return &SeparatedNode { left, right, true, node.Start, node.End }
+    default: panic("Invalid separator operation")
+    }
 }
 
-func (v ParseTreeVisitor) VisitStringExpr(node *parser.ParseTreeNode) AST {
-    str := node.STRING().(parser.Token)
+func (v ParseTreeVisitor) VisitRepeatExpr(node parser.RepeatExprNode) AST {
+    // Upper bound is equal to lower bound if omitted, and is unbounded if only the comma is given
+    low := parseBound(node.Min()); high := low
+    if m, ok := node.M().(*parser.ParseTreeNode); ok {
+        if t, ok := m.Max().(parser.Token); ok { high = parseBound(t) } else { high = UNBOUNDED }
+    }
+    location := node.Start
+    switch {
+    case high != UNBOUNDED && low > high:
+        Error(fmt.Sprintf("Invalid repetition bounds {%d,%d} - %d:%d", low, high, location.Line, location.Col))
+    case high == 0:
+        Error(fmt.Sprintf("Repetition must allow at least one occurrence - %d:%d", location.Line, location.Col))
+    }
+    return &RepeatRangeNode { parser.VisitNode(v, node.Expr()), low, high, location, node.End }
+}
+
+func (v ParseTreeVisitor) VisitTemplateExpr(node parser.TemplateExprNode) AST {
+    id := node.Id()
+    identifier := &IdentifierNode { id.Value, id.Start, id.End }
+    arguments := []AST { parser.VisitNode(v, node.Expr()) }
+    for _, n := range node.A().(*parser.ParseTreeNode).Children {
+        arguments = append(arguments, parser.VisitNode(v, n.(*parser.ParseTreeNode).Expr()))
+    }
+    return &TemplateNode { identifier, arguments, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitGroupExpr(node parser.GroupExprNode) AST { return parser.VisitNode(v, node.Expr()) }
+func (v ParseTreeVisitor) VisitIdentifierExpr(node parser.IdentifierExprNode) AST {
+    return &IdentifierNode { node.Id().Value, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitStringExpr(node parser.StringExprNode) AST {
+    str := node.STRING()
     value := str.Value[1:len(str.Value) - 1] // Remove quotation marks
-    return &StringNode { reduceString([]rune(value)), node.Start, node.End }
+    return &StringNode { reduceString([]rune(value)), false, node.Start, node.End }
+}
+
+func (v ParseTreeVisitor) VisitNocaseStringExpr(node parser.NocaseStringExprNode) AST {
+    str := node.ISTRING()
+    value := str.Value[2:len(str.Value) - 1] // Remove prefix and quotation marks
+    return &StringNode { reduceString([]rune(value)), true, node.Start, node.End }
 }
 
-func (v ParseTreeVisitor) VisitClassExpr(node *parser.ParseTreeNode) AST {
-    class := node.CLASS().(parser.Token)
+func (v ParseTreeVisitor) VisitClassExpr(node parser.ClassExprNode) AST {
+    class := node.CLASS()
     value := class.Value[1:len(class.Value) - 1] // Remove brackets
     // If caret occurs, flag class as negated and remove caret
     negated, location := len(value) > 0 && value[0] == '^', node.Start
     var expanded []parser.Range
     if !negated {
-        expanded = expandClass(reduceString([]rune(value)), location)
+        expanded = parseClass([]rune(value), location)
     } else {
-        expanded = negateRanges(expandClass(reduceString([]rune(value[1:])), location))
+        expanded = negateRanges(parseClass([]rune(value[1:]), location))
     }
     return &ClassNode { expanded, location, node.End }
 }
 
-func (v ParseTreeVisitor) VisitErrorExpr(node *parser.ParseTreeNode) AST { return &ErrorNode { node.Start, node.End } }
-func (v ParseTreeVisitor) VisitAnyExpr(node *parser.ParseTreeNode) AST {
+func (v ParseTreeVisitor) VisitErrorExpr(node parser.ErrorExprNode) AST { return &ErrorNode { node.Start, node.End } }
+func (v ParseTreeVisitor) VisitAnyExpr(node parser.AnyExprNode) AST {
     location := node.Start
     return &ClassNode { negateRanges(expandClass([]rune { '\n', '\r' }, location)), location, node.End }
 }
 
+func parseBound(token parser.Token) int {
+    n, err := strconv.Atoi(token.Value)
+    if err != nil {
+        Error(fmt.Sprintf("Invalid repetition bound \"%s\" - %d:%d", token.Value, token.Start.Line, token.Start.Col))
+    }
+    return n
+}
+
 func reduceString(chars []rune) []rune {
     result := make([]rune, 0, len(chars))
     for i := 0; i < len(chars); i++ {
@@ -255,6 +536,53 @@ func reduceString(chars []rune) []rune {
     return result
 }
 
+func parseClass(chars []rune, location parser.Location) []parser.Range {
+    // Split class into segments separated by Unicode class escapes (\p{...} and \P{...})
+    ranges, start := make([]parser.Range, 0), 0
+    for i := 0; i < len(chars); i++ {
+        if chars[i] != '\\' || i + 1 >= len(chars) { continue }
+        c := chars[i + 1]
+        if (c != 'p' && c != 'P') || i + 2 >= len(chars) || chars[i + 2] != '{' { i++; continue } // Skip escaped character
+        end := slices.Index(chars[i + 3:], '}')
+        if end == -1 {
+            Error(fmt.Sprintf("Unterminated Unicode class escape - %d:%d", location.Line, location.Col))
+            return ranges
+        }
+        // Expand preceding segment separately so hyphen notation cannot span a Unicode class escape
+        ranges = append(ranges, expandClass(reduceString(chars[start:i]), location)...)
+        ranges = append(ranges, unicodeRanges(string(chars[i + 3:i + 3 + end]), c == 'P', location)...)
+        i += 3 + end; start = i + 1
+    }
+    ranges = append(ranges, expandClass(reduceString(chars[start:]), location)...)
+    if len(ranges) <= 1 { return ranges }
+    return mergeRanges(ranges)
+}
+
+func unicodeRanges(name string, negated bool, location parser.Location) []parser.Range {
+    // Find range table in general categories, scripts, or properties provided by the unicode package
+    table, ok := unicode.Categories[name]
+    if !ok { table, ok = unicode.Scripts[name] }
+    if !ok { table, ok = unicode.Properties[name] }
+    if !ok {
+        Error(fmt.Sprintf("Unicode class \"%s\" is not defined - %d:%d", name, location.Line, location.Col))
+        return nil
+    }
+    // Convert range table to range structs, strided ranges are split into individual characters
+    ranges := make([]parser.Range, 0, len(table.R16) + len(table.R32))
+    add := func (low, high, stride uint32) {
+        if stride == 1 {
+            ranges = append(ranges, parser.Range { Min: rune(low), Max: rune(high) })
+            return
+        }
+        for c := low; c <= high; c += stride { ranges = append(ranges, parser.Range { Min: rune(c), Max: rune(c) }) }
+    }
+    for _, r := range table.R16 { add(uint32(r.Lo), uint32(r.Hi), uint32(r.Stride)) }
+    for _, r := range table.R32 { add(r.Lo, r.Hi, r.Stride) }
+    ranges = mergeRanges(ranges)
+    if negated { return negateRanges(ranges) }
+    return ranges
+}
+
 func expandClass(chars []rune, location parser.Location) []parser.Range {
     // Convert characters and hyphen notation to range structs
     expanded := make([]parser.Range, 0, len(chars))
@@ -305,6 +633,32 @@ func negateRanges(ranges []parser.Range) []parser.Range {
     return negated
 }
 
+func unionRanges(a, b []parser.Range) []parser.Range {
+    ranges := append(slices.Clone(a), b...)
+    if len(ranges) == 0 { return ranges }
+    return mergeRanges(ranges)
+}
+
+func intersectRanges(a, b []parser.Range) []parser.Range {
+    // Intersection is the complement of the union of complements
+    intersection := negateRanges(unionRanges(negateRanges(a), negateRanges(b)))
+    // Null character is excluded by negation, so restore it if both sets contain it
+    if len(a) > 0 && a[0].Min == 0 && len(b) > 0 && b[0].Min == 0 {
+        intersection = unionRanges(intersection, []parser.Range { { Min: 0, Max: 0 } })
+    }
+    return intersection
+}
+
+func subtractRanges(a, b []parser.Range) []parser.Range {
+    // Difference is the intersection with the complement of the subtracted set
+    difference := intersectRanges(a, negateRanges(b))
+    // Null character is excluded by negation, so restore it if only the first set contains it
+    if len(a) > 0 && a[0].Min == 0 && !(len(b) > 0 && b[0].Min == 0) {
+        difference = unionRanges(difference, []parser.Range { { Min: 0, Max: 0 } })
+    }
+    return difference
+}
+
 func max[T cmp.Ordered](a, b T) T {
     if a > b { return a }
     return b
@@ -314,33 +668,78 @@ func max[T cmp.Ordered](a, b T) T {
 
 func (n GrammarNode) String() string {
     lines := make([]string, 0, len(n.Rules) + len(n.Tokens) + len(n.Fragments))
+    for _, option := range n.Options { lines = append(lines, option.String()) }
+    for _, entry := range n.Entries { lines = append(lines, entry.String()) }
     for _, rule := range n.Rules { lines = append(lines, rule.String()) }
     for _, token := range n.Tokens { lines = append(lines, token.String()) }
     for _, fragment := range n.Fragments { lines = append(lines, fragment.String()) }
     return strings.Join(lines, "\n")
 }
 
-func (n RuleNode) String() string { return fmt.Sprintf("rule %s : %v", n.Identifier, n.Expression) }
+func (n RuleNode) String() string {
+    var inline string
+    if n.Inline { inline = "inline " }
+    if len(n.Parameters) > 0 {
+        parameters := make([]string, len(n.Parameters))
+        for i, p := range n.Parameters { parameters[i] = p.String() }
+        return fmt.Sprintf("%srule %s<%s> : %v", inline, n.Identifier, strings.Join(parameters, ", "), n.Expression)
+    }
+    return fmt.Sprintf("%srule %s : %v", inline, n.Identifier, n.Expression)
+}
 func (n PrecedenceNode) String() string {
     var assoc string
-    if n.Associativity == LEFT_ASSOC {
-        assoc = "left"
-    } else {
-        assoc = "right"
+    switch n.Associativity {
+    case LEFT_ASSOC:  assoc = "left"
+    case RIGHT_ASSOC: assoc = "right"
+    case NON_ASSOC:   assoc = "nonassoc"
+    default: return fmt.Sprintf("prec %s", n.Identifier)
     }
-    return fmt.Sprintf("prec %s : %s", n.Identifier, assoc)
+    tokens := make([]string, len(n.Tokens))
+    for i, t := range n.Tokens { tokens[i] = " " + t.String() }
+    return fmt.Sprintf("prec %s : %s%s", n.Identifier, assoc, strings.Join(tokens, ""))
 }
 func (n TokenNode) String() string {
-    if n.Skip {
-        return fmt.Sprintf("token skip %s : %v", n.Identifier, n.Expression)
+    actions := make([]string, 0)
+    if n.Skip { actions = append(actions, "skip") }
+    if n.Hidden { actions = append(actions, fmt.Sprintf("channel(%s)", HIDDEN_CHANNEL)) }
+    if n.NoCase { actions = append(actions, "nocase") }
+    if n.Action != nil { actions = append(actions, n.Action.String()) }
+    if len(actions) > 0 {
+        return fmt.Sprintf("token [%s] %s : %v -> %s", n.Mode, n.Identifier, n.Expression, strings.Join(actions, ", "))
     }
-    return fmt.Sprintf("token %s : %v", n.Identifier, n.Expression)
+    return fmt.Sprintf("token [%s] %s : %v", n.Mode, n.Identifier, n.Expression)
 }
 func (n FragmentNode) String() string { return fmt.Sprintf("frag %s : %v", n.Identifier, n.Expression) }
+func (n ModeNode) String() string { return fmt.Sprintf("mode %s", n.Identifier) }
+func (n EntryNode) String() string { return fmt.Sprintf("start %s", n.Identifier) }
+func (n GrammarOptionNode) String() string { return fmt.Sprintf("option %s", n.Identifier) }
+func (n ImportNode) String() string { return fmt.Sprintf("import %q", n.Path) }
+
+func (n SkipNode) String() string { return "skip" }
+func (n ChannelNode) String() string { return fmt.Sprintf("channel(%s)", n.Identifier) }
+func (n NoCaseNode) String() string { return "nocase" }
+func (n ModeActionNode) String() string {
+    switch n.Type {
+    case PUSH_MODE: return fmt.Sprintf("pushMode(%s)", n.Mode)
+    case POP_MODE:  return "popMode"
+    default:        return fmt.Sprintf("mode(%s)", n.Mode)
+    }
+}
 
 func (n OptionNode) String() string { return fmt.Sprintf("(%v)?", n.Expression) }
 func (n RepeatNode) String() string { return fmt.Sprintf("(%v)*", n.Expression) }
 func (n RepeatOneNode) String() string { return fmt.Sprintf("(%v)+", n.Expression) }
+func (n SeparatedNode) String() string {
+    if n.NonEmpty { return fmt.Sprintf("(%v) %%+ (%v)", n.Expression, n.Separator) }
+    return fmt.Sprintf("(%v) %% (%v)", n.Expression, n.Separator)
+}
+func (n RepeatRangeNode) String() string {
+    switch n.Max {
+    case n.Min:     return fmt.Sprintf("(%v){%d}", n.Expression, n.Min)
+    case UNBOUNDED: return fmt.Sprintf("(%v){%d,}", n.Expression, n.Min)
+    default:        return fmt.Sprintf("(%v){%d,%d}", n.Expression, n.Min, n.Max)
+    }
+}
 
 func (n LabelNode) String() string {
     var precedence string
@@ -348,12 +747,24 @@ func (n LabelNode) String() string {
     return fmt.Sprintf("(%v) #%s%s", n.Expression, n.Identifier, precedence)
 }
 func (n AliasNode) String() string { return fmt.Sprintf("(%s = %v)", n.Identifier, n.Expression) }
+func (n DropNode) String() string { return fmt.Sprintf("!(%v)", n.Expression) }
+func (n HoistNode) String() string { return fmt.Sprintf("^(%v)", n.Expression) }
 
 func (n ConcatNode) String() string { return fmt.Sprintf("(%v %v)", n.A, n.B) }
 func (n UnionNode) String() string { return fmt.Sprintf("(%v | %v)", n.A, n.B) }
+func (n DifferenceNode) String() string { return fmt.Sprintf("(%v - %v)", n.A, n.B) }
+func (n IntersectionNode) String() string { return fmt.Sprintf("(%v && %v)", n.A, n.B) }
 
+func (n TemplateNode) String() string {
+    arguments := make([]string, len(n.Arguments))
+    for i, a := range n.Arguments { arguments[i] = fmt.Sprint(a) }
+    return fmt.Sprintf("%s<%s>", n.Identifier, strings.Join(arguments, ", "))
+}
 func (n IdentifierNode) String() string { return fmt.Sprintf("id:%s", n.Name) }
-func (n StringNode) String() string { return fmt.Sprintf("%q", string(n.Chars)) }
+func (n StringNode) String() string {
+    if n.Insensitive { return fmt.Sprintf("i%q", string(n.Chars)) }
+    return fmt.Sprintf("%q", string(n.Chars))
+}
 func (n ClassNode) String() string {
     ranges := make([]string, len(n.Ranges))
     for i, r := range n.Ranges { ranges[i] = r.String() }
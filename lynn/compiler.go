@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"lynn/lynn/parser"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -18,12 +19,19 @@ func Error(message string) {
     fmt.Fprintf(os.Stderr, "Generation error: %s\n", message)
     occurred = true
 }
+// Reports warnings as errors if enabled.
+var WarningsAsErrors = false
+// Reports a warning message.
+func Warning(message string) {
+    if WarningsAsErrors { Error(message); return }
+    fmt.Fprintf(os.Stderr, "Generation warning: %s\n", message)
+}
 
 //go:embed spec/**/*.template
 var f embed.FS
 
 // Compiles relevant lexer data to lexer program in Go.
-func CompileLexerGo(name string, dfa LDFA, ranges []parser.Range, grammar *GrammarNode) {
+func CompileLexerGo(name string, dfa []LDFA, ranges []parser.Range, grammar *GrammarNode) {
     const LEXER_TEMPLATE string = "spec/go/lexer.template"
     // Read template information
     data, err := f.ReadFile(LEXER_TEMPLATE)
@@ -32,7 +40,7 @@ func CompileLexerGo(name string, dfa LDFA, ranges []parser.Range, grammar *Gramm
     // Format token type information
     tokens, typeName := make([]string, len(grammar.Tokens)), make([]string, len(grammar.Tokens))
     tokenIndices := make(map[string]int, len(grammar.Tokens))
-    skip := make([]string, 0)
+    skip, hidden := make([]string, 0), make([]string, 0)
     for i, token := range grammar.Tokens {
         id := token.Identifier.Name
         tokens[i], tokenIndices[id] = id, i
@@ -40,6 +48,9 @@ func CompileLexerGo(name string, dfa LDFA, ranges []parser.Range, grammar *Gramm
         if token.Skip {
             skip = append(skip, fmt.Sprintf("%d: {}", i))
         }
+        if token.Hidden {
+            hidden = append(hidden, fmt.Sprintf("%d: {}", i))
+        }
     }
     tokens[0] += " TokenType = iota"
     // Format range information
@@ -49,29 +60,45 @@ func CompileLexerGo(name string, dfa LDFA, ranges []parser.Range, grammar *Gramm
     for i, r := range ranges {
         rangeStrings[i] = fmt.Sprintf("{ %q, %q }", r.Min, r.Max)
     }
+    // Format mode information
+    modeIndices := make(map[string]int, len(grammar.Modes))
+    for i, mode := range grammar.Modes { modeIndices[mode.Identifier.Name] = i }
+    actions := make([]string, 0)
+    for i, token := range grammar.Tokens {
+        if a := token.Action; a != nil {
+            var mode int; if a.Mode != nil { mode = modeIndices[a.Mode.Name] }
+            actions = append(actions, fmt.Sprintf("%d: { %d, %d }", i, a.Type, mode))
+        }
+    }
     // Format state information
-    stateIndices := map[*LDFAState]int { dfa.Start: 0 }
-    transitions := make([]string, len(dfa.States))
-    for _, state := range dfa.States {
-        if state != dfa.Start { stateIndices[state] = len(stateIndices) }
+    // States of all modes are numbered consecutively, with the start state of each mode listed first
+    stateIndices, starts := make(map[*LDFAState]int), make([]string, len(dfa))
+    for i, d := range dfa {
+        starts[i] = strconv.Itoa(len(stateIndices))
+        stateIndices[d.Start] = len(stateIndices)
+        for _, state := range d.States {
+            if state != d.Start { stateIndices[state] = len(stateIndices) }
+        }
     }
-    for _, state := range dfa.States {
-        i, l := stateIndices[state], len(state.Transitions)
-        if l == 0 {
-            transitions[i] = "    { },"
-            continue
+    transitions, accept := make([]string, len(stateIndices)), make([]string, 0)
+    for _, d := range dfa {
+        for _, state := range d.States {
+            i, l := stateIndices[state], len(state.Transitions)
+            if l == 0 {
+                transitions[i] = "    { },"
+                continue
+            }
+            // Format outgoing transitions for each state
+            out := make([]string, 0, l)
+            for _, r := range sortedKeys(state.Transitions, func (a, b parser.Range) int { return rangeIndices[a] - rangeIndices[b] }) {
+                out = append(out, fmt.Sprintf("%d: %d", rangeIndices[r], stateIndices[state.Transitions[r]]))
+            }
+            transitions[i] = fmt.Sprintf("    { %s },", strings.Join(out, ", "))
         }
-        // Format outgoing transitions for each state
-        out := make([]string, 0, l)
-        for r, state := range state.Transitions {
-            out = append(out, fmt.Sprintf("%d: %d", rangeIndices[r], stateIndices[state]))
+        // Format accepting states
+        for _, state := range sortedKeys(d.Accept, func (a, b *LDFAState) int { return stateIndices[a] - stateIndices[b] }) {
+            accept = append(accept, fmt.Sprintf("%d: %d", stateIndices[state], tokenIndices[d.Accept[state]]))
         }
-        transitions[i] = fmt.Sprintf("    { %s },", strings.Join(out, ", "))
-    }
-    // Format accepting states
-    accept := make([]string, 0, len(dfa.Accept))
-    for state, token := range dfa.Accept {
-        accept = append(accept, fmt.Sprintf("%d: %d", stateIndices[state], tokenIndices[token]))
     }
     // Replace sections with compiled DFA
     pairs := []string {
@@ -82,6 +109,9 @@ func CompileLexerGo(name string, dfa LDFA, ranges []parser.Range, grammar *Gramm
         "/*{4}*/", strings.Join(rangeStrings, ", "),
         "/*{5}*/", strings.Join(transitions, "\n"),
         "/*{6}*/", strings.Join(accept, ", "),
+        "/*{7}*/", strings.Join(starts, ", "),
+        "/*{8}*/", strings.Join(actions, ", "),
+        "/*{9}*/", strings.Join(hidden, ", "),
     }
     result := strings.NewReplacer(pairs...).Replace(template)
     // Write modified template to lexer program file
@@ -105,25 +135,30 @@ func CompileParserGo(name string, table LRParseTable, maps map[*Production]map[s
     for i, token := range grammar.Tokens {
         tokenIndices[token.Identifier.Name] = i
     }
-    // Get non-terminal indices (skip augmented start non-terminal)
-    l := len(table.Grammar.NonTerminals) - 1
+    // Get non-terminal indices (skip augmented start non-terminals)
+    l := len(table.Grammar.NonTerminals) - len(table.Starts)
     nonTerminalIndices := make(map[NonTerminal]int, l)
     for i, t := range table.Grammar.NonTerminals[:l] {
         nonTerminalIndices[t] = i
     }
     // Format production data
-    // Remove last production, which is the augmented start production
-    productions := make([]string, len(table.Grammar.Productions) - 1)
+    // Remove last productions, which are the augmented start productions
+    productions := make([]string, len(table.Grammar.Productions) - len(table.Starts))
+    // Generate typed nodes for each visitor
+    nodes, declarations, wrappers := compileNodesGo(table.Grammar, table.Grammar.Productions[:len(productions)], maps)
+    if Panic() { return }
     existingVisitors, existingAliases := make(map[string]struct{}), make(map[string]struct{})
     visitors, dispatchers := make([]string, 0), make([]string, 0)
+    listeners, baseListeners, enters, exits := make([]string, 0), make([]string, 0), make([]string, 0), make([]string, 0)
+    defaults := make([]string, 0)
     aliases := make([]string, 0)
     for i, p := range table.Grammar.Productions[:len(productions)] {
         var out string
         if m, ok := maps[p]; ok {
             // If alias map is present, compile to string
             entries := make([]string, 0, len(m))
-            for id, i := range m {
-                entries = append(entries, fmt.Sprintf("\"%s\": %d", id, i))
+            for _, id := range sortedKeys(m, strings.Compare) {
+                entries = append(entries, fmt.Sprintf("\"%s\": %d", id, m[id]))
                 // Track all unique aliases
                 if _, ok := existingAliases[id]; ok { continue }
                 existingAliases[id] = struct{}{}
@@ -137,49 +172,74 @@ func CompileParserGo(name string, table LRParseTable, maps map[*Production]map[s
         } else {
             out = "nil"
         }
-        productions[i] = fmt.Sprintf("    { %d, %d, %d, \"%s\", %s },",
-            p.Type, nonTerminalIndices[p.Left], len(p.Right), p.Visitor, out)
-        // Test if new dispatcher needs to be generated
-        if len(p.Visitor) == 0 { continue }
+        // Format shape of production, if present
+        dropped, hoist := "nil", -1
+        if shape, ok := table.Grammar.Shapes[p]; ok {
+            if slices.Contains(shape.Dropped, true) { dropped = fmt.Sprintf("[]bool { %s }", formatBools(shape.Dropped)) }
+            hoist = shape.Hoist
+        }
+        productions[i] = fmt.Sprintf("    { %d, %d, %d, \"%s\", %s, %s, %d },",
+            p.Type, nonTerminalIndices[p.Left], len(p.Right), p.Visitor, out, dropped, hoist)
+        // Test if new dispatcher needs to be generated, hoisted productions never generate nodes
+        if len(p.Visitor) == 0 || hoist >= 0 { continue }
         if _, ok := existingVisitors[p.Visitor]; ok { continue }
         existingVisitors[p.Visitor] = struct{}{}
-        // Add visitor entries and dispatcher lines
+        // Add visitor entries and dispatcher lines, visitors are passed the typed node of their label
         n := []rune(p.Visitor); n[0] = unicode.ToUpper(n[0]) // Capitalize first character
-        visitor := string(n)
-        visitors = append(visitors, fmt.Sprintf("    Visit%s(node *ParseTreeNode) T", visitor))
-        dispatchers = append(dispatchers, fmt.Sprintf("        case \"%s\": return visitor.Visit%s(n)", p.Visitor, visitor))
-    }
-    // Format action table
-    parseTable := make([]string, len(table.Action))
-    for i := range len(parseTable) {
-        actions, gotos := table.Action[i], table.Goto[i]
-        var actionEntries, gotoEntries string
-        if len(actions) > 0 {
-            // Format action entries for each state
-            out := make([]string, 0, len(actions))
-            for t, entry := range actions {
-                out = append(out, fmt.Sprintf("%d: { %d, %d }", tokenIndices[string(t)], entry.Type, entry.Value))
-            }
-            actionEntries = fmt.Sprintf("{ %s }", strings.Join(out, ", "))
-        } else { actionEntries = "{ }" }
-        if len(gotos) > 0 {
-            // Format goto entry for each state
-            out := make([]string, 0, len(gotos))
-            for t, state := range gotos {
-                out = append(out, fmt.Sprintf("%d: %d", nonTerminalIndices[t], state))
-            }
-            gotoEntries = fmt.Sprintf("{ %s }", strings.Join(out, ", "))
-        } else { gotoEntries = "{ }"}
-        parseTable[i] = fmt.Sprintf("    { map[int]actionEntry %s, map[int]int %s },", actionEntries, gotoEntries)
+        visitor, node := string(n), nodes[p.Visitor]
+        visitors = append(visitors, fmt.Sprintf("    Visit%s(node %s) T", visitor, node.param))
+        dispatchers = append(dispatchers, fmt.Sprintf("            case \"%s\": return visitor.Visit%s(%s { n })", p.Visitor, visitor, node.name))
+        // Add listener entries, no-op implementations, and enter and exit dispatcher lines
+        listeners = append(listeners, fmt.Sprintf("    Enter%s(node %s)\n    Exit%s(node %s)", visitor, node.param, visitor, node.param))
+        baseListeners = append(baseListeners, fmt.Sprintf("func (BaseListener) Enter%s(node %s) { }\nfunc (BaseListener) Exit%s(node %s) { }",
+            visitor, node.param, visitor, node.param))
+        enters = append(enters, fmt.Sprintf("        case \"%s\": listener.Enter%s(%s { n })", p.Visitor, visitor, node.name))
+        exits = append(exits, fmt.Sprintf("        case \"%s\": listener.Exit%s(%s { n })", p.Visitor, visitor, node.name))
+        defaults = append(defaults, fmt.Sprintf("func (v DefaultVisitor[T]) Visit%s(node %s) T { return v.VisitChildren(node.ParseTree()) }",
+            visitor, node.param))
+    }
+    // Compress action and goto tables
+    packed := packTable(table, tokenIndices, nonTerminalIndices)
+    // Format the non-associative precedence levels rejecting tokens in each state that has any
+    errors := make([]string, 0)
+    for i, e := range table.Errors {
+        if len(e) == 0 { continue }
+        out := make([]string, 0, len(e))
+        for _, t := range sortedKeys(e, func (a, b Terminal) int { return tokenIndices[string(a)] - tokenIndices[string(b)] }) {
+            out = append(out, fmt.Sprintf("%d: %q", tokenIndices[string(t)], e[t]))
+        }
+        errors = append(errors, fmt.Sprintf("    %d: { %s },", i, strings.Join(out, ", ")))
+    }
+    // Generate parse methods for each start rule, which begin parsing from the rule's start state
+    entries := make([]string, 0, len(table.Grammar.Entries))
+    for _, t := range table.Grammar.Entries {
+        n := []rune(string(t)); n[0] = unicode.ToUpper(n[0]) // Capitalize first character
+        entries = append(entries, fmt.Sprintf("func (p *Parser) Parse%s() *ParseTreeNode { return p.parse(%d) }",
+            string(n), slices.Index(table.Starts, t)))
     }
     // Replace sections with compiled parse table
     pairs := []string {
         "/*{0}*/", name,
         "/*{1}*/", strings.Join(productions, "\n"),
-        "/*{2}*/", strings.Join(parseTable, "\n"),
+        "/*{2}*/", formatInts(packed.actionBase, "    "),
         "/*{3}*/", strings.Join(visitors, "\n"),
         "/*{4}*/", strings.Join(dispatchers, "\n"),
         "/*{5}*/", strings.Join(aliases, "\n"),
+        "/*{6}*/", strings.Join(entries, "\n"),
+        "/*{7}*/", strings.Join(declarations, "\n\n"),
+        "/*{8}*/", strings.Join(wrappers, "\n"),
+        "/*{9}*/", strings.Join(listeners, "\n"),
+        "/*{10}*/", strings.Join(baseListeners, "\n"),
+        "/*{11}*/", strings.Join(enters, "\n"),
+        "/*{12}*/", strings.Join(exits, "\n"),
+        "/*{13}*/", strings.Join(defaults, "\n"),
+        "/*{14}*/", formatInts(packed.actionCheck, "    "),
+        "/*{15}*/", formatInts(packed.actionValue, "    "),
+        "/*{16}*/", formatInts(packed.gotoBase, "    "),
+        "/*{17}*/", formatInts(packed.gotoCheck, "    "),
+        "/*{18}*/", formatInts(packed.gotoValue, "    "),
+        "/*{19}*/", formatInts(packed.gotoDefault, "    "),
+        "/*{20}*/", strings.Join(errors, "\n"),
     }
     result := strings.NewReplacer(pairs...).Replace(template)
     // Write modified template to lexer program file
@@ -188,12 +248,216 @@ func CompileParserGo(name string, table LRParseTable, maps map[*Production]map[s
     if err != nil { panic(err) }
     defer f.Close()
     f.WriteString(result)
+    if table.Conflicts != nil { compileGLRGo(name, table, tokenIndices) }
+}
+
+// Compiles conflicting actions of a parse table and the GLR parser driver to a separate program file in Go.
+func compileGLRGo(name string, table LRParseTable, tokenIndices map[string]int) {
+    const GLR_TEMPLATE string = "spec/go/glr.template"
+    // Read template information
+    data, err := f.ReadFile(GLR_TEMPLATE)
+    if err != nil { panic(err) }
+    template := string(data)
+    // Format conflicting actions of each state that has any
+    conflicts := make([]string, 0)
+    for i, c := range table.Conflicts {
+        if len(c) == 0 { continue }
+        out := make([]string, 0, len(c))
+        for _, t := range sortedKeys(c, func (a, b Terminal) int { return tokenIndices[string(a)] - tokenIndices[string(b)] }) {
+            entries := c[t]
+            actions := make([]string, len(entries))
+            for j, entry := range entries { actions[j] = fmt.Sprintf("{ %d, %d }", entry.Type, entry.Value) }
+            out = append(out, fmt.Sprintf("%d: { %s }", tokenIndices[string(t)], strings.Join(actions, ", ")))
+        }
+        conflicts = append(conflicts, fmt.Sprintf("    %d: { %s },", i, strings.Join(out, ", ")))
+    }
+    // Generate GLR parse methods for each start rule
+    entries := make([]string, 0, len(table.Grammar.Entries))
+    for _, t := range table.Grammar.Entries {
+        entries = append(entries, fmt.Sprintf("func (p *Parser) ParseGLR%s() ParseTreeChild { return p.parseGLR(%d) }",
+            capitalize(string(t)), slices.Index(table.Starts, t)))
+    }
+    pairs := []string {
+        "/*{0}*/", name,
+        "/*{1}*/", strings.Join(conflicts, "\n"),
+        "/*{2}*/", strings.Join(entries, "\n"),
+    }
+    result := strings.NewReplacer(pairs...).Replace(template)
+    f, err := os.Create("out/glr.go")
+    if err != nil { panic(err) }
+    defer f.Close()
+    f.WriteString(result)
+}
+
+// Typed node struct. Holds the name of the struct generated for a visitor and the type its visitor method is passed.
+type typedNode struct { name, param string }
+
+// Node kinds that do not correspond to a visitor, which cannot collide with visitor names.
+const (TOKEN_KIND string = "<token>"; UNTYPED_KIND = "<node>"; NIL_KIND = "<nil>")
+
+// Types declared by the parser template, which typed nodes and rule interfaces cannot be named after.
+var reservedNodesGo = []string { "ParseTreeNode", "AmbiguityNode" }
+
+// Generates a typed node struct for each visitor, with accessors typed by the kinds of children each alias may refer to.
+// Rules deriving nodes of multiple visitors are given an interface implemented by the typed nodes of each visitor.
+// Returns typed node information for each visitor, the type declarations, and the cases used to wrap parse tree nodes.
+func compileNodesGo(grammar *Grammar, productions []*Production, maps map[*Production]map[string]int) (map[string]typedNode, []string, []string) {
+    // Find the kinds of children each non-terminal may derive, which are either visitors, tokens, untyped nodes, or nil
+    kinds := make(map[NonTerminal]map[string]struct{}, len(grammar.NonTerminals))
+    for _, p := range productions { kinds[p.Left] = make(map[string]struct{}) }
+    symbolKinds := func (s Symbol) map[string]struct{} {
+        if t, ok := s.(NonTerminal); ok { return kinds[t] }
+        return map[string]struct{} { TOKEN_KIND: { } }
+    }
+    for changed := true; changed; {
+        // Propagate kinds through auxiliary productions and hoisted symbols until no new kinds are found
+        changed = false
+        for _, p := range productions {
+            var derived map[string]struct{}
+            switch p.Type {
+            case NORMAL:
+                if shape, ok := grammar.Shapes[p]; ok && shape.Hoist >= 0 {
+                    derived = symbolKinds(p.Right[shape.Hoist])
+                } else if p.Visitor == "" {
+                    derived = map[string]struct{} { UNTYPED_KIND: { } }
+                } else {
+                    derived = map[string]struct{} { p.Visitor: { } }
+                }
+            case AUXILIARY: derived = symbolKinds(p.Right[0])
+            case REMOVED:   derived = map[string]struct{} { NIL_KIND: { } }
+            default: continue
+            }
+            for k := range derived {
+                if _, ok := kinds[p.Left][k]; !ok { kinds[p.Left][k] = struct{}{}; changed = true }
+            }
+        }
+    }
+    root := func (t NonTerminal) NonTerminal {
+        if parent, ok := grammar.Parents[t]; ok { return parent }
+        return t
+    }
+    // Find the visitors whose nodes may be derived from each rule
+    order, roots := make([]string, 0), make([]NonTerminal, 0)
+    implementers := make(map[NonTerminal]map[string]struct{})
+    for _, p := range productions {
+        if shape, ok := grammar.Shapes[p]; p.Visitor != "" && !(ok && shape.Hoist >= 0) && !slices.Contains(order, p.Visitor) {
+            order = append(order, p.Visitor)
+        }
+        r := root(p.Left)
+        if _, ok := implementers[r]; !ok { implementers[r] = make(map[string]struct{}); roots = append(roots, r) }
+        for k := range kinds[p.Left] {
+            if k != TOKEN_KIND && k != UNTYPED_KIND && k != NIL_KIND { implementers[r][k] = struct{}{} }
+        }
+    }
+    // Generate an interface for each rule that may derive nodes of multiple visitors
+    interfaces, interfaceRoots := make(map[NonTerminal]string), make(map[string]NonTerminal)
+    declarations := make([]string, 0)
+    for _, r := range roots {
+        if len(implementers[r]) < 2 { continue }
+        name := capitalize(string(r)) + "Node"
+        if slices.Contains(reservedNodesGo, name) {
+            Error(fmt.Sprintf("Rule \"%s\" generates interface \"%s\", which is reserved by the parser", r, name))
+        }
+        interfaces[r], interfaceRoots[name] = name, r
+        declarations = append(declarations, fmt.Sprintf("// Node interface implemented by the typed nodes that rule %s may derive.\n" +
+            "type %s interface { ParseTreeChild; ParseTree() *ParseTreeNode; is%s() }", r, name, name))
+    }
+    // Typed nodes with the same name as an interface are unexported, and their visitors are passed the interface instead
+    nodes := make(map[string]typedNode, len(order))
+    for _, v := range order {
+        name := capitalize(v) + "Node"
+        if slices.Contains(reservedNodesGo, name) {
+            Error(fmt.Sprintf("Label \"%s\" generates typed node \"%s\", which is reserved by the parser", v, name))
+        }
+        if r, ok := interfaceRoots[name]; ok {
+            implementers[r][v] = struct{}{}
+            n := []rune(name); n[0] = unicode.ToLower(n[0])
+            nodes[v] = typedNode { string(n), name }
+        } else {
+            nodes[v] = typedNode { name, name }
+        }
+    }
+    // Determine the accessor type for an alias given the kinds of children it may refer to
+    accessorType := func (k map[string]struct{}, rules map[NonTerminal]struct{}) string {
+        visitors := make([]string, 0, len(k))
+        for v := range k {
+            if v != TOKEN_KIND && v != UNTYPED_KIND && v != NIL_KIND { visitors = append(visitors, v) }
+        }
+        _, token := k[TOKEN_KIND]; _, untyped := k[UNTYPED_KIND]; _, null := k[NIL_KIND]
+        switch {
+        case untyped || token && len(visitors) > 0: return ""
+        case token && null: return "*Token"
+        case token:         return "Token"
+        case len(visitors) == 1: return nodes[visitors[0]].param
+        case len(visitors) > 1 && len(rules) == 1:
+            for r := range rules { return interfaces[r] }
+        }
+        return ""
+    }
+    wrappers := make([]string, 0, len(order))
+    for _, v := range order {
+        node := nodes[v]
+        lines := []string { fmt.Sprintf("// Typed node passed to Visit%s.\ntype %s struct { *ParseTreeNode }", capitalize(v), node.name) }
+        for _, r := range roots {
+            if _, ok := implementers[r][v]; ok && interfaces[r] != "" {
+                lines = append(lines, fmt.Sprintf("func (%s) is%s() { }", node.name, interfaces[r]))
+            }
+        }
+        // Collect the kinds of children each alias may refer to across the productions of the visitor
+        count, aliases := 0, make([]string, 0)
+        aliasKinds, aliasRules := make(map[string]map[string]struct{}), make(map[string]map[NonTerminal]struct{})
+        aliasCounts := make(map[string]int)
+        for _, p := range productions {
+            if p.Type != NORMAL || p.Visitor != v { continue }
+            shape, shaped := grammar.Shapes[p]
+            if shaped && shape.Hoist >= 0 { continue } // Hoisted productions do not generate nodes
+            count++
+            for alias, i := range maps[p] {
+                // Find the symbol the alias refers to, alias indices skip dropped symbols
+                j := i
+                if shaped && shape.Dropped != nil {
+                    for k, kept := 0, -1; k < len(shape.Dropped); k++ {
+                        if !shape.Dropped[k] { kept++; if kept == i { j = k; break } }
+                    }
+                }
+                s := p.Right[j]
+                if _, ok := aliasKinds[alias]; !ok {
+                    aliasKinds[alias], aliasRules[alias] = make(map[string]struct{}), make(map[NonTerminal]struct{})
+                    aliases = append(aliases, alias)
+                }
+                aliasCounts[alias]++
+                for k := range symbolKinds(s) { aliasKinds[alias][k] = struct{}{} }
+                if t, ok := s.(NonTerminal); ok { aliasRules[alias][root(t)] = struct{}{} }
+            }
+        }
+        slices.Sort(aliases)
+        for _, alias := range aliases {
+            // Aliases missing from some productions may refer to nil
+            if aliasCounts[alias] < count { aliasKinds[alias][NIL_KIND] = struct{}{} }
+            t := accessorType(aliasKinds[alias], aliasRules[alias])
+            switch t {
+            case "": continue // Untyped aliases use the parse tree node accessor
+            case "Token":
+                lines = append(lines, fmt.Sprintf("func (n %s) %s() Token { t, _ := n.GetAlias(\"%s\").(Token); return t }",
+                    node.name, capitalize(alias), alias))
+            case "*Token":
+                lines = append(lines, fmt.Sprintf("func (n %s) %s() *Token { if t, ok := n.GetAlias(\"%s\").(Token); ok { return &t }; return nil }",
+                    node.name, capitalize(alias), alias))
+            default:
+                lines = append(lines, fmt.Sprintf("func (n %s) %s() %s { c, _ := wrapNode(n.GetAlias(\"%s\")).(%s); return c }",
+                    node.name, capitalize(alias), t, alias, t))
+            }
+        }
+        declarations = append(declarations, strings.Join(lines, "\n"))
+        wrappers = append(wrappers, fmt.Sprintf("        case \"%s\": return %s { n }", v, node.name))
+    }
+    return nodes, declarations, wrappers
 }
 
 // ------------------------------------------------------------------------------------------------------------------------------
 
 // Compiles relevant lexer data to lexer program in TypeScript.
-func CompileLexerTS(dfa LDFA, ranges []parser.Range, grammar *GrammarNode) {
+func CompileLexerTS(dfa []LDFA, ranges []parser.Range, grammar *GrammarNode) {
     const LEXER_TEMPLATE string = "spec/ts/lexer.template"
     // Read template information
     data, err := f.ReadFile(LEXER_TEMPLATE)
@@ -202,12 +466,13 @@ func CompileLexerTS(dfa LDFA, ranges []parser.Range, grammar *GrammarNode) {
     // Format token type information
     tokens, typeName := make([]string, len(grammar.Tokens)), make([]string, len(grammar.Tokens))
     tokenIndices := make(map[string]int, len(grammar.Tokens))
-    skip := make([]string, 0)
+    skip, hidden := make([]string, 0), make([]string, 0)
     for i, token := range grammar.Tokens {
         id := token.Identifier.Name
         tokens[i], tokenIndices[id] = id, i
         typeName[i] = fmt.Sprintf("[%d, \"%s\"]", i, id)
         if token.Skip { skip = append(skip, strconv.Itoa(i)) }
+        if token.Hidden { hidden = append(hidden, strconv.Itoa(i)) }
     }
     // Format range information
     rangeIndices := make(map[parser.Range]int, len(ranges))
@@ -216,29 +481,45 @@ func CompileLexerTS(dfa LDFA, ranges []parser.Range, grammar *GrammarNode) {
     for i, r := range ranges {
         rangeStrings[i] = fmt.Sprintf("new Range(%d, %d)", r.Min, r.Max)
     }
+    // Format mode information
+    modeIndices := make(map[string]int, len(grammar.Modes))
+    for i, mode := range grammar.Modes { modeIndices[mode.Identifier.Name] = i }
+    actions := make([]string, 0)
+    for i, token := range grammar.Tokens {
+        if a := token.Action; a != nil {
+            var mode int; if a.Mode != nil { mode = modeIndices[a.Mode.Name] }
+            actions = append(actions, fmt.Sprintf("[%d, [%d, %d]]", i, a.Type, mode))
+        }
+    }
     // Format state information
-    stateIndices := map[*LDFAState]int { dfa.Start: 0 }
-    transitions := make([]string, len(dfa.States))
-    for _, state := range dfa.States {
-        if state != dfa.Start { stateIndices[state] = len(stateIndices) }
+    // States of all modes are numbered consecutively, with the start state of each mode listed first
+    stateIndices, starts := make(map[*LDFAState]int), make([]string, len(dfa))
+    for i, d := range dfa {
+        starts[i] = strconv.Itoa(len(stateIndices))
+        stateIndices[d.Start] = len(stateIndices)
+        for _, state := range d.States {
+            if state != d.Start { stateIndices[state] = len(stateIndices) }
+        }
     }
-    for _, state := range dfa.States {
-        i, l := stateIndices[state], len(state.Transitions)
-        if l == 0 {
-            transitions[i] = "        new Map(),"
-            continue
+    transitions, accept := make([]string, len(stateIndices)), make([]string, 0)
+    for _, d := range dfa {
+        for _, state := range d.States {
+            i, l := stateIndices[state], len(state.Transitions)
+            if l == 0 {
+                transitions[i] = "        new Map(),"
+                continue
+            }
+            // Format outgoing transitions for each state
+            out := make([]string, 0, l)
+            for _, r := range sortedKeys(state.Transitions, func (a, b parser.Range) int { return rangeIndices[a] - rangeIndices[b] }) {
+                out = append(out, fmt.Sprintf("[%d, %d]", rangeIndices[r], stateIndices[state.Transitions[r]]))
+            }
+            transitions[i] = fmt.Sprintf("        new Map([%s]),", strings.Join(out, ", "))
         }
-        // Format outgoing transitions for each state
-        out := make([]string, 0, l)
-        for r, state := range state.Transitions {
-            out = append(out, fmt.Sprintf("[%d, %d]", rangeIndices[r], stateIndices[state]))
+        // Format accepting states
+        for _, state := range sortedKeys(d.Accept, func (a, b *LDFAState) int { return stateIndices[a] - stateIndices[b] }) {
+            accept = append(accept, fmt.Sprintf("[%d, %d]", stateIndices[state], tokenIndices[d.Accept[state]]))
         }
-        transitions[i] = fmt.Sprintf("        new Map([%s]),", strings.Join(out, ", "))
-    }
-    // Format accepting states
-    accept := make([]string, 0, len(dfa.Accept))
-    for state, token := range dfa.Accept {
-        accept = append(accept, fmt.Sprintf("[%d, %d]", stateIndices[state], tokenIndices[token]))
     }
     // Replace sections with compiled DFA
     pairs := []string {
@@ -248,6 +529,9 @@ func CompileLexerTS(dfa LDFA, ranges []parser.Range, grammar *GrammarNode) {
         "/*{3}*/", strings.Join(transitions, "\n"),
         "/*{4}*/", strings.Join(accept, ", "),
         "/*{5}*/", strings.Join(typeName, ", "),
+        "/*{6}*/", strings.Join(starts, ", "),
+        "/*{7}*/", strings.Join(actions, ", "),
+        "/*{8}*/", strings.Join(hidden, ", "),
     }
     result := strings.NewReplacer(pairs...).Replace(template)
     // Write modified template to lexer program file
@@ -271,25 +555,27 @@ func CompileParserTS(table LRParseTable, maps map[*Production]map[string]int, gr
     for i, token := range grammar.Tokens {
         tokenIndices[token.Identifier.Name] = i
     }
-    // Get non-terminal indices (skip augmented start non-terminal)
-    l := len(table.Grammar.NonTerminals) - 1
+    // Get non-terminal indices (skip augmented start non-terminals)
+    l := len(table.Grammar.NonTerminals) - len(table.Starts)
     nonTerminalIndices := make(map[NonTerminal]int, l)
     for i, t := range table.Grammar.NonTerminals[:l] {
         nonTerminalIndices[t] = i
     }
     // Format production data
-    // Remove last production, which is the augmented start production
-    productions := make([]string, len(table.Grammar.Productions) - 1)
+    // Remove last productions, which are the augmented start productions
+    productions := make([]string, len(table.Grammar.Productions) - len(table.Starts))
     existingVisitors, existingAliases := make(map[string]struct{}), make(map[string]struct{})
     visitors, dispatchers := make([]string, 0), make([]string, 0)
+    listeners, baseListeners, enters, exits := make([]string, 0), make([]string, 0), make([]string, 0), make([]string, 0)
+    defaults := make([]string, 0)
     aliases := make([]string, 0)
     for i, p := range table.Grammar.Productions[:len(productions)] {
         var out string
         if m, ok := maps[p]; ok {
             // If alias map is present, compile to string
             entries := make([]string, 0, len(m))
-            for id, i := range m {
-                entries = append(entries, fmt.Sprintf("[\"%s\", %d]", id, i))
+            for _, id := range sortedKeys(m, strings.Compare) {
+                entries = append(entries, fmt.Sprintf("[\"%s\", %d]", id, m[id]))
                 // Track all unique aliases
                 if _, ok := existingAliases[id]; ok { continue }
                 existingAliases[id] = struct{}{}
@@ -300,10 +586,16 @@ func CompileParserTS(table LRParseTable, maps map[*Production]map[string]int, gr
         } else {
             out = "null"
         }
-        productions[i] = fmt.Sprintf("        new ProductionData(%d, %d, %d, \"%s\", %s),",
-            p.Type, nonTerminalIndices[p.Left], len(p.Right), p.Visitor, out)
-        // Test if new dispatcher needs to be generated
-        if len(p.Visitor) == 0 { continue }
+        // Format shape of production, if present
+        dropped, hoist := "null", -1
+        if shape, ok := table.Grammar.Shapes[p]; ok {
+            if slices.Contains(shape.Dropped, true) { dropped = fmt.Sprintf("[%s]", formatBools(shape.Dropped)) }
+            hoist = shape.Hoist
+        }
+        productions[i] = fmt.Sprintf("        new ProductionData(%d, %d, %d, \"%s\", %s, %s, %d),",
+            p.Type, nonTerminalIndices[p.Left], len(p.Right), p.Visitor, out, dropped, hoist)
+        // Test if new dispatcher needs to be generated, hoisted productions never generate nodes
+        if len(p.Visitor) == 0 || hoist >= 0 { continue }
         if _, ok := existingVisitors[p.Visitor]; ok { continue }
         existingVisitors[p.Visitor] = struct{}{}
         // Add visitor entries and dispatcher lines
@@ -311,37 +603,52 @@ func CompileParserTS(table LRParseTable, maps map[*Production]map[string]int, gr
         visitor := string(n)
         visitors = append(visitors, fmt.Sprintf("    visit%s(node: ParseTreeNode): T", visitor))
         dispatchers = append(dispatchers, fmt.Sprintf("        case \"%s\": return visitor.visit%s(node)", p.Visitor, visitor))
+        // Add listener entries, no-op implementations, and enter and exit dispatcher lines
+        listeners = append(listeners, fmt.Sprintf("    enter%s(node: ParseTreeNode): void\n    exit%s(node: ParseTreeNode): void", visitor, visitor))
+        baseListeners = append(baseListeners, fmt.Sprintf("    public enter%s(node: ParseTreeNode) { }\n    public exit%s(node: ParseTreeNode) { }", visitor, visitor))
+        enters = append(enters, fmt.Sprintf("        case \"%s\": listener.enter%s(tree); break", p.Visitor, visitor))
+        exits = append(exits, fmt.Sprintf("        case \"%s\": listener.exit%s(tree); break", p.Visitor, visitor))
+        defaults = append(defaults, fmt.Sprintf("    public visit%s(node: ParseTreeNode): T { return this.visitChildren(node) }", visitor))
     }
-    // Format action table
-    parseTable := make([]string, len(table.Action))
-    for i := range len(parseTable) {
-        actions, gotos := table.Action[i], table.Goto[i]
-        var actionEntries, gotoEntries string
-        if len(actions) > 0 {
-            // Format action entries for each state
-            out := make([]string, 0, len(actions))
-            for t, entry := range actions {
-                out = append(out, fmt.Sprintf("[%d, new ActionEntry(%d, %d)]", tokenIndices[string(t)], entry.Type, entry.Value))
-            }
-            actionEntries = fmt.Sprintf("[%s]", strings.Join(out, ", "))
-        }
-        if len(gotos) > 0 {
-            // Format goto entry for each state
-            out := make([]string, 0, len(gotos))
-            for t, state := range gotos {
-                out = append(out, fmt.Sprintf("[%d, %d]", nonTerminalIndices[t], state))
-            }
-            gotoEntries = fmt.Sprintf("[%s]", strings.Join(out, ", "))
+    // Compress action and goto tables
+    packed := packTable(table, tokenIndices, nonTerminalIndices)
+    // Format the non-associative precedence levels rejecting tokens in each state that has any
+    errors := make([]string, 0)
+    for i, e := range table.Errors {
+        if len(e) == 0 { continue }
+        out := make([]string, 0, len(e))
+        for _, t := range sortedKeys(e, func (a, b Terminal) int { return tokenIndices[string(a)] - tokenIndices[string(b)] }) {
+            out = append(out, fmt.Sprintf("[%d, %q]", tokenIndices[string(t)], e[t]))
         }
-        parseTable[i] = fmt.Sprintf("        new TableEntry(new Map(%s), new Map(%s)),", actionEntries, gotoEntries)
+        errors = append(errors, fmt.Sprintf("        [%d, new Map([%s])],", i, strings.Join(out, ", ")))
+    }
+    // Generate parse methods for each start rule, which begin parsing from the rule's start state
+    entries := make([]string, 0, len(table.Grammar.Entries))
+    for _, t := range table.Grammar.Entries {
+        n := []rune(string(t)); n[0] = unicode.ToUpper(n[0]) // Capitalize first character
+        entries = append(entries, fmt.Sprintf("    public parse%s(): ParseTreeNode | null { return this.parseFrom(%d) }",
+            string(n), slices.Index(table.Starts, t)))
     }
     // Replace sections with compiled parse table
     pairs := []string {
         "/*{0}*/", strings.Join(aliases, "\n"),
         "/*{1}*/", strings.Join(productions, "\n"),
-        "/*{2}*/", strings.Join(parseTable, "\n"),
+        "/*{2}*/", formatInts(packed.actionBase, "        "),
         "/*{3}*/", strings.Join(visitors, "\n"),
         "/*{4}*/", strings.Join(dispatchers, "\n"),
+        "/*{5}*/", strings.Join(entries, "\n"),
+        "/*{6}*/", strings.Join(listeners, "\n"),
+        "/*{7}*/", strings.Join(baseListeners, "\n"),
+        "/*{8}*/", strings.Join(enters, "\n"),
+        "/*{9}*/", strings.Join(exits, "\n"),
+        "/*{10}*/", strings.Join(defaults, "\n"),
+        "/*{11}*/", formatInts(packed.actionCheck, "        "),
+        "/*{12}*/", formatInts(packed.actionValue, "        "),
+        "/*{13}*/", formatInts(packed.gotoBase, "        "),
+        "/*{14}*/", formatInts(packed.gotoCheck, "        "),
+        "/*{15}*/", formatInts(packed.gotoValue, "        "),
+        "/*{16}*/", formatInts(packed.gotoDefault, "        "),
+        "/*{17}*/", strings.Join(errors, "\n"),
     }
     result := strings.NewReplacer(pairs...).Replace(template)
     // Write modified template to lexer program file
@@ -351,3 +658,115 @@ func CompileParserTS(table LRParseTable, maps map[*Production]map[string]int, gr
     defer f.Close()
     f.WriteString(result)
 }
+
+// ------------------------------------------------------------------------------------------------------------------------------
+
+// Compressed parse table struct. Rows of the action and goto tables are packed into comb vectors using row displacement.
+// The entry of a row at a column is found at the row's base plus the column, and only belongs to the row if its check value
+// is the row's base. Action rows are indexed by state, and goto rows by non-terminal with states as columns.
+type packedTable struct {
+    actionBase, actionCheck, actionValue []int
+    gotoBase, gotoCheck, gotoValue       []int
+    gotoDefault                          []int // Goto entries that are not found in a row default to the state listed here
+}
+
+// Compresses the action and goto tables of a parse table.
+// Actions are encoded with the action type in the lowest two bits, and the error terminal is placed in the first column.
+// Goto entries are never looked up for missing entries, so the most common state of each non-terminal is used as its default.
+func packTable(table LRParseTable, tokenIndices map[string]int, nonTerminalIndices map[NonTerminal]int) packedTable {
+    actions := make([]map[int]int, len(table.Action))
+    for i, row := range table.Action {
+        actions[i] = make(map[int]int, len(row))
+        for t, entry := range row { actions[i][tokenIndices[string(t)] + 1] = entry.Value << 2 | int(entry.Type) }
+    }
+    gotos, defaults := make([]map[int]int, len(nonTerminalIndices)), make([]int, len(nonTerminalIndices))
+    for i := range gotos { gotos[i] = make(map[int]int) }
+    for state, row := range table.Goto {
+        for t, next := range row {
+            // Augmented start non-terminals never appear in the goto table
+            if i, ok := nonTerminalIndices[t]; ok { gotos[i][state] = next }
+        }
+    }
+    for i, row := range gotos {
+        // Find the most common state of the row, ties are broken by the lowest state
+        counts := make(map[int]int)
+        for _, next := range row { counts[next]++ }
+        for next, c := range counts {
+            if c > counts[defaults[i]] || c == counts[defaults[i]] && next < defaults[i] { defaults[i] = next }
+        }
+        for state, next := range row {
+            if next == defaults[i] { delete(row, state) }
+        }
+    }
+    packed := packedTable { gotoDefault: defaults }
+    packed.actionBase, packed.actionCheck, packed.actionValue = packRows(actions)
+    packed.gotoBase, packed.gotoCheck, packed.gotoValue = packRows(gotos)
+    return packed
+}
+
+// Packs sparse rows into a comb vector, returning the base of each row and the check and value of each slot.
+// Rows are placed at the first base where their entries do not overlap the entries of rows that were already placed.
+// Identical rows share a base, while different rows are given unique bases so the check value identifies the row.
+func packRows(rows []map[int]int) ([]int, []int, []int) {
+    base, check, value := make([]int, len(rows)), make([]int, 0), make([]int, 0)
+    placed, used := make(map[string]int), make(map[int]struct{})
+    // Place rows with the most entries first, which leaves gaps that are filled by smaller rows
+    order := make([]int, len(rows))
+    for i := range order { order[i] = i }
+    slices.SortStableFunc(order, func (a, b int) int { return len(rows[b]) - len(rows[a]) })
+    free := 0 // All slots before this index are occupied
+    for _, r := range order {
+        columns := sortedKeys(rows[r], func (a, b int) int { return a - b })
+        key := make([]string, len(columns))
+        for i, c := range columns { key[i] = fmt.Sprintf("%d:%d", c, rows[r][c]) }
+        if b, ok := placed[strings.Join(key, " ")]; ok { base[r] = b; continue }
+        // Slots before the first free slot are occupied, so the first column of the row cannot be placed before it
+        b := 0
+        if len(columns) > 0 { b = max(free - columns[0], 0) }
+        for ; ; b++ {
+            if _, ok := used[b]; ok { continue }
+            if !slices.ContainsFunc(columns, func (c int) bool { return b + c < len(check) && check[b + c] != -1 }) { break }
+        }
+        for _, c := range columns {
+            for len(check) <= b + c { check = append(check, -1); value = append(value, 0) }
+            check[b + c], value[b + c] = b, rows[r][c]
+        }
+        for free < len(check) && check[free] != -1 { free++ }
+        base[r], placed[strings.Join(key, " ")], used[b] = b, b, struct{}{}
+    }
+    return base, check, value
+}
+
+// Formats a list of integers as comma-separated literals, with a fixed number of values on each indented line.
+func formatInts(values []int, indent string) string {
+    const LINE_LENGTH int = 24
+    lines := make([]string, 0, len(values) / LINE_LENGTH + 1)
+    for i := 0; i < len(values); i += LINE_LENGTH {
+        out := make([]string, 0, LINE_LENGTH)
+        for _, v := range values[i:min(i + LINE_LENGTH, len(values))] { out = append(out, strconv.Itoa(v)) }
+        lines = append(lines, indent + strings.Join(out, ", ") + ",")
+    }
+    return strings.Join(lines, "\n")
+}
+
+// Formats a list of booleans as comma-separated literals, which are identical in Go and TypeScript.
+func formatBools(values []bool) string {
+    out := make([]string, len(values))
+    for i, v := range values { out[i] = strconv.FormatBool(v) }
+    return strings.Join(out, ", ")
+}
+
+// Returns the keys of a map in the order given by the comparison function.
+func sortedKeys[K comparable, V any](m map[K]V, compare func (a, b K) int) []K {
+    keys := make([]K, 0, len(m))
+    for k := range m { keys = append(keys, k) }
+    slices.SortFunc(keys, compare)
+    return keys
+}
+
+// Returns the string with its first character capitalized.
+func capitalize(s string) string {
+    if s == "" { return s }
+    n := []rune(s); n[0] = unicode.ToUpper(n[0])
+    return string(n)
+}
@@ -0,0 +1,145 @@
+package lynn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Runs the full generator on a grammar file and returns the contents of the generated programs.
+func generate(t *testing.T, path string, mode TableMode, lang string) map[string][]byte {
+    t.Helper()
+    loader := NewGrammarLoader()
+    ast := loader.Load(path)
+    if Panic() { t.Fatalf("failed to load %s", path) }
+    generator := NewLexerGenerator(loader)
+    nfa, ranges := generator.GenerateNFA(ast)
+    dfa := make([]LDFA, len(nfa))
+    for i, n := range nfa { dfa[i] = generator.NFAtoDFA(n, ranges) }
+    grammar, maps := NewGrammarGenerator().GenerateCFG(ast)
+    if Panic() { t.Fatalf("failed to generate grammar for %s", path) }
+    table := NewLALRParserGenerator(mode, false).Generate(grammar)
+    if Panic() { t.Fatalf("failed to generate parse table for %s", path) }
+    switch lang {
+    case "go": CompileLexerGo("parser", dfa, ranges, ast); CompileParserGo("parser", table, maps, ast)
+    case "ts": CompileLexerTS(dfa, ranges, ast); CompileParserTS(table, maps, ast)
+    }
+    files, err := filepath.Glob("out/*")
+    if err != nil { t.Fatal(err) }
+    output := make(map[string][]byte, len(files))
+    for _, file := range files {
+        data, err := os.ReadFile(file)
+        if err != nil { t.Fatal(err) }
+        output[filepath.Base(file)] = data
+    }
+    return output
+}
+
+func TestDeterministicOutput(t *testing.T) {
+    const RUNS int = 5
+    // Canonical LR(1) construction is slow on large grammars, so the grammar language is only tested in LALR mode
+    grammars := map[string][]TableMode {
+        "spec/lynn.ln":           { LALR_TABLE },
+        "testdata/precedence.ln": { LALR_TABLE, LR1_TABLE, MINIMAL_LR1_TABLE },
+    }
+    names := []string { "lalr", "lr1", "minimal" }
+    for grammar, modes := range grammars {
+        path, err := filepath.Abs(grammar)
+        if err != nil { t.Fatal(err) }
+        for _, mode := range modes {
+            name := names[mode]
+            for _, lang := range []string { "go", "ts" } {
+                t.Run(filepath.Base(grammar) + "/" + name + "/" + lang, func (t *testing.T) {
+                    t.Chdir(t.TempDir())
+                    expected := generate(t, path, mode, lang)
+                    for range RUNS - 1 {
+                        for file, data := range generate(t, path, mode, lang) {
+                            if !bytes.Equal(data, expected[file]) { t.Fatalf("%s differs between runs", file) }
+                        }
+                    }
+                })
+            }
+        }
+    }
+}
+
+// Keywords added after the first release remain valid as rule, token, mode, and label names.
+func TestKeywordsAsNames(t *testing.T) {
+    path, err := filepath.Abs("testdata/keywords.ln")
+    if err != nil { t.Fatal(err) }
+    t.Chdir(t.TempDir())
+    output := generate(t, path, LALR_TABLE, "go")
+    for _, name := range []string { "VisitStart(", "VisitPushMode(", "VisitImport(", "VisitInline(" } {
+        if !bytes.Contains(output["parser.go"], []byte(name)) { t.Errorf("parser.go does not contain %s", name) }
+    }
+}
+
+// Program that parses its input with the generated GLR parser, then prints the number of statements and ambiguity nodes.
+const GLR_MAIN string = `package main
+
+import ("fmt"; "os")
+
+func count(child ParseTreeChild) (int, int) {
+    statements, ambiguities := 0, 0
+    var children []ParseTreeChild
+    switch n := child.(type) {
+    case *ParseTreeNode:
+        if n.data.visitor == "exprStmt" { statements++ }
+        children = n.Children
+    case *AmbiguityNode: ambiguities++; children = n.Alternatives
+    }
+    for _, c := range children { s, a := count(c); statements += s; ambiguities += a }
+    return statements, ambiguities
+}
+
+func main() {
+    tree := NewParser(NewLexer(os.Stdin, DEFAULT_LEXER_HANDLER), DEFAULT_PARSER_HANDLER).ParseGLR()
+    if tree == nil { os.Exit(1) }
+    fmt.Println(count(tree))
+}
+`
+
+func TestGLRParser(t *testing.T) {
+    path, err := filepath.Abs("testdata/glr.ln")
+    if err != nil { t.Fatal(err) }
+    t.Chdir(t.TempDir())
+    loader := NewGrammarLoader()
+    ast := loader.Load(path)
+    if Panic() { t.Fatal("failed to load grammar") }
+    generator := NewLexerGenerator(loader)
+    nfa, ranges := generator.GenerateNFA(ast)
+    dfa := make([]LDFA, len(nfa))
+    for i, n := range nfa { dfa[i] = generator.NFAtoDFA(n, ranges) }
+    grammar, maps := NewGrammarGenerator().GenerateCFG(ast)
+    table := NewLALRParserGenerator(LALR_TABLE, true).Generate(grammar)
+    if Panic() { t.Fatal("failed to generate parse table") }
+    CompileLexerGo("main", dfa, ranges, ast); CompileParserGo("main", table, maps, ast)
+    if err := os.WriteFile("out/main.go", []byte(GLR_MAIN), 0644); err != nil { t.Fatal(err) }
+    if err := os.WriteFile("out/go.mod", []byte("module glr\n\ngo 1.24\n"), 0644); err != nil { t.Fatal(err) }
+    build := exec.Command("go", "build", "-o", "glr", ".")
+    build.Dir = "out"
+    if output, err := build.CombinedOutput(); err != nil { t.Fatalf("failed to build GLR parser: %v\n%s", err, output) }
+    run := func (input string) string {
+        // Quadratic list construction runs out of time or memory on long lists
+        ctx, cancel := context.WithTimeout(context.Background(), 30 * time.Second)
+        defer cancel()
+        cmd := exec.CommandContext(ctx, "./out/glr")
+        cmd.Stdin = strings.NewReader(input)
+        output, err := cmd.Output()
+        if err != nil { t.Fatalf("failed to parse input: %v", err) }
+        return strings.TrimSpace(string(output))
+    }
+    // Each ambiguous sum is represented by an ambiguity node with a derivation for each grouping
+    if output := run("a + b + c; d;"); output != "2 1" { t.Errorf("expected 2 statements and 1 ambiguity node, got %s", output) }
+    // Lists are only built once, rather than copied for every element
+    const LENGTH int = 40000
+    if output, expected := run(strings.Repeat("x;\n", LENGTH)), fmt.Sprintf("%d 0", LENGTH); output != expected {
+        t.Errorf("expected %s, got %s", expected, output)
+    }
+}
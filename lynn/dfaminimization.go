@@ -51,7 +51,20 @@ func minimizeDFA[T DFAValue](dfa DFA[T], values []T) DFA[T] {
             }
         }
     }
-    return mergeIndistinguishable(dfa, partition)
+    return orderStates(mergeIndistinguishable(dfa, partition), values)
+}
+
+// Orders the states of a DFA canonically, in breadth-first order from the start state.
+// Transitions of each state are followed in the order of the given values, so equivalent DFAs list their states identically.
+func orderStates[T DFAValue](dfa DFA[T], values []T) DFA[T] {
+    states, visited := []*DFAState[T] { dfa.Start }, map[*DFAState[T]]struct{} { dfa.Start: {} }
+    for i := 0; i < len(states); i++ {
+        for _, value := range values {
+            next, ok := states[i].Transitions[value]; if !ok { continue }
+            if _, ok := visited[next]; !ok { visited[next] = struct{}{}; states = append(states, next) }
+        }
+    }
+    return DFA[T] { dfa.Start, states, dfa.Accept }
 }
 
 // Separate all DFA states based on whether they are accepting or non-accepting.
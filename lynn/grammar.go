@@ -2,6 +2,8 @@ package lynn
 
 import (
 	"fmt"
+	"lynn/lynn/parser"
+	"slices"
 	"strings"
 )
 
@@ -14,11 +16,32 @@ const (EPSILON Terminal = ""; ERROR_TERMINAL = "error"; EOF_TERMINAL = "EOF")
 type NonTerminal string
 
 // Grammar struct. Tracks all terminals and non-terminals, the start non-terminal, and all production rules.
+// Entries are the non-terminals declared by start statements, which the parser may also start from.
+// Terminals and productions may be assigned precedence levels, which are used to resolve shift/reduce conflicts.
+// Parents map each derived non-terminal to the rule or template it was derived from.
 type Grammar struct {
     Terminals    []Terminal
     NonTerminals []NonTerminal
     Start        NonTerminal
+    Entries      []NonTerminal
     Productions  []*Production
+    TerminalPrecedence   map[Terminal]PrecedenceLevel
+    ProductionPrecedence map[*Production]PrecedenceLevel
+    Shapes               map[*Production]Shape
+    Parents              map[NonTerminal]NonTerminal
+}
+
+// Production shape struct. Specifies how the parse tree node generated by a normal production is modified.
+type Shape struct {
+    Dropped []bool // Symbols whose children are omitted from the node
+    Hoist   int    // Index of the symbol whose child replaces the node, -1 if no child is hoisted
+}
+
+// Precedence level struct. Levels with a greater order have higher precedence.
+type PrecedenceLevel struct {
+    Order         int
+    Associativity AssociativityType
+    Name          string
 }
 
 // Production type enum. Either NORMAL, AUXILIARY, FLATTEN, OR REMOVED.
@@ -26,7 +49,8 @@ type ProductionType uint
 const (NORMAL ProductionType = iota; AUXILIARY; FLATTEN; REMOVED)
 // Production struct. Expresses a sequence of symbols that a given non-terminal may be expanded to in a grammar.
 // Auxiliary productions must have a right-hand side with a single non-terminal.
-// Flatten productions must follow the form E -> E E_0.
+// Flatten productions must follow the form E -> E E_0 (or E_k -> E_{k - 1} E_0 for bounded repetitions).
+// Flatten productions of separated lists follow the form E -> E S E_0, where the separator S is dropped.
 // Removed productions must have a length of 0 (epsilon productions).
 type Production struct {
     Type    ProductionType
@@ -44,9 +68,21 @@ type GrammarGenerator struct {
     children       map[NonTerminal]int
     productions    []*Production
     aliasMaps      map[*Production]map[string]int
+    shapes         map[*Production]Shape
+    dropLiterals   bool
     labels         map[*Production]*LabelNode
+    precedence     map[string]int
+    associativity  []AssociativityType
+    templates      map[string]*RuleNode
+    inlines        map[string]*RuleNode
+    inlining       map[string]struct{}
+    instances      map[string]NonTerminal
+    depth          int
 }
 
+// Maximum number of nested template instantiations, prevents templates from expanding indefinitely.
+const MAX_TEMPLATE_DEPTH int = 16
+
 // Returns a grammar generator struct.
 func NewGrammarGenerator() *GrammarGenerator { return &GrammarGenerator { } }
 // Converts EBNF rules defined in AST into CFG production rules.
@@ -55,7 +91,7 @@ func (g *GrammarGenerator) GenerateCFG(grammar *GrammarNode) (*Grammar, map[*Pro
     g.terminals, g.strings = make(map[string]struct{}, len(grammar.Tokens)), make(map[string]Terminal, len(grammar.Tokens))
     terminals := make([]Terminal, 0, len(grammar.Tokens))
     for _, token := range grammar.Tokens {
-        if token.Skip { continue }
+        if token.Skip || token.Hidden { continue }
         id := token.Identifier
         if _, ok := g.terminals[id.Name]; ok {
             Error(fmt.Sprintf("Token \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
@@ -77,6 +113,8 @@ func (g *GrammarGenerator) GenerateCFG(grammar *GrammarNode) (*Grammar, map[*Pro
     // Create list of valid non-terminals and initialize parent-children relationship data constructs
     g.nonTerminals, g.nonTerminalMap = make([]NonTerminal, 0, len(grammar.Rules)), make(map[string]struct{}, len(grammar.Rules))
     g.parents, g.children = make(map[NonTerminal]NonTerminal), make(map[NonTerminal]int, len(grammar.Rules))
+    g.templates, g.instances, g.depth = make(map[string]*RuleNode), make(map[string]NonTerminal), 0
+    g.inlines, g.inlining = make(map[string]*RuleNode), make(map[string]struct{})
     for _, rule := range grammar.Rules {
         id := rule.Identifier
         // Ensure identifier does not collide with an existing token
@@ -84,25 +122,92 @@ func (g *GrammarGenerator) GenerateCFG(grammar *GrammarNode) (*Grammar, map[*Pro
             Error(fmt.Sprintf("Identifier \"%s\" is already taken by a token - %d:%d", id.Name, id.Start.Line, id.Start.Col))
             continue
         }
+        // Inline rules do not generate non-terminals, and are instead substituted where they are used
+        if rule.Inline {
+            if len(rule.Parameters) > 0 {
+                Error(fmt.Sprintf("Inline rule \"%s\" cannot have parameters - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+                continue
+            }
+            if _, ok := g.inlines[id.Name]; ok {
+                Error(fmt.Sprintf("Inline rule \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+                continue
+            }
+            // Labels are removed from the alternatives of the rule once, rather than reported wherever it is used
+            inline := *rule; inline.Expression = removeLabels(rule.Expression)
+            g.inlines[id.Name] = &inline
+            continue
+        }
+        // Templates only generate non-terminals when they are instantiated
+        if len(rule.Parameters) > 0 {
+            if _, ok := g.templates[id.Name]; ok {
+                Error(fmt.Sprintf("Template \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+                continue
+            }
+            g.templates[id.Name] = rule
+            continue
+        }
         if _, ok := g.nonTerminalMap[id.Name]; !ok {
             g.nonTerminals = append(g.nonTerminals, NonTerminal(id.Name))
             g.nonTerminalMap[id.Name] = struct{}{}
         }
     }
+    for _, rule := range grammar.Rules {
+        id := rule.Identifier
+        if _, ok := g.nonTerminalMap[id.Name]; ok && (len(rule.Parameters) > 0 || rule.Inline) {
+            Error(fmt.Sprintf("Identifier \"%s\" is already taken by a rule - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+            continue
+        }
+        if _, ok := g.templates[id.Name]; ok && rule.Inline {
+            Error(fmt.Sprintf("Identifier \"%s\" is already taken by a template - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+        }
+    }
+    if len(g.nonTerminals) == 0 {
+        Error("Grammar definition must contain at least one rule that is not a template or inline rule")
+        return nil, nil
+    }
     // Convert AST expression to grammar
     g.productions = make([]*Production, 0)
     g.aliasMaps, g.labels = make(map[*Production]map[string]int), make(map[*Production]*LabelNode)
+    g.shapes = make(map[*Production]Shape)
+    g.dropLiterals = slices.ContainsFunc(grammar.Options, func (n *GrammarOptionNode) bool { return n.Identifier.Name == DROP_LITERALS_OPTION })
     for _, rule := range grammar.Rules {
+        if len(rule.Parameters) > 0 || rule.Inline { continue }
         t := NonTerminal(rule.Identifier.Name)
-        g.flattenProductions(t, rule.Expression)
+        g.flattenProductions(t, rule.Expression, string(t))
+    }
+    g.readPrecedence(grammar.Precedence)
+    g.removeAmbiguities()
+    terminalPrecedence, productionPrecedence := g.assignPrecedence(grammar.Precedence)
+    // Ensure start statements refer to rules
+    entries := make([]NonTerminal, 0, len(grammar.Entries))
+    for _, entry := range grammar.Entries {
+        id := entry.Identifier; t := NonTerminal(id.Name)
+        if _, ok := g.nonTerminalMap[id.Name]; !ok || g.parents[t] != "" {
+            Error(fmt.Sprintf("Rule \"%s\" is not defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+            continue
+        }
+        if slices.Contains(entries, t) {
+            Error(fmt.Sprintf("Start rule \"%s\" is already declared - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+            continue
+        }
+        entries = append(entries, t)
+    }
+    // Ensure the root of the parse tree is a node, start rules cannot hoist a token or missing child
+    for _, rule := range grammar.Rules {
+        id := rule.Identifier; t := NonTerminal(id.Name)
+        if len(rule.Parameters) > 0 || rule.Inline || (t != g.nonTerminals[0] && !slices.Contains(entries, t)) { continue }
+        if !g.derivesNode(t, make(map[NonTerminal]struct{})) {
+            Error(fmt.Sprintf("Start rule \"%s\" cannot hoist a token or missing child - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+        }
     }
-    g.removeAmbiguities(grammar.Precedence)
     // Collect accumulated data into grammar struct
-    return &Grammar { terminals, g.nonTerminals, g.nonTerminals[0], g.productions }, g.aliasMaps
+    return &Grammar { terminals, g.nonTerminals, g.nonTerminals[0], entries, g.productions, terminalPrecedence, productionPrecedence, g.shapes, g.parents },
+        g.aliasMaps
 }
 
 // For a given expression node from the AST, adds to a list of productions in CFG format.
-func (g *GrammarGenerator) flattenProductions(left NonTerminal, expression AST) {
+// Productions without a label are given the provided visitor name.
+func (g *GrammarGenerator) flattenProductions(left NonTerminal, expression AST, name string) {
     // Find all production cases for a given non-terminal by obtaining leaf nodes in a union
     var cases []AST
     if n, ok := expression.(*UnionNode); ok {
@@ -119,17 +224,25 @@ func (g *GrammarGenerator) flattenProductions(left NonTerminal, expression AST)
             node = label.Expression
             visitor = label.Identifier.Name
         } else {
-            visitor = string(left)
+            visitor = name
         }
         // For each case, flatten concatenated nodes and convert nodes in list to symbols
         // Associate production with label node for later use in disambiguation
-        production := g.flattenConcatCFG(left, node, visitor)
-        if ok { g.labels[production] = label }
+        productions := g.flattenConcatCFG(left, node, visitor)
+        if ok {
+            for _, p := range productions { g.labels[p] = label }
+        }
     }
 }
 
 // Determines whether or not parts of an expression needs to be expanded to a new non-terminal.
 func (g *GrammarGenerator) expandExpressionCFG(left NonTerminal, expression AST) Symbol {
+    // Inline rules that cannot be spliced into a sequence are expanded like a group
+    if rule, ok := g.inlineRule(expression); ok {
+        if rule == nil { return nil }
+        defer delete(g.inlining, rule.Identifier.Name)
+        return g.expandExpressionCFG(left, rule.Expression)
+    }
     // For literals, convert and return terminal directly
     s, ok := g.literalCFG(expression); if ok { return s }
     // Otherwise, create new non-terminal and expand expression
@@ -166,6 +279,53 @@ func (g *GrammarGenerator) expressionCFG(left NonTerminal, expression AST) {
                 &Production { FLATTEN, left, []Symbol { left, t }, "" },
                 &Production { NORMAL,  left, []Symbol { t }, "" })
         }
+    case *RepeatRangeNode:
+        // Unbounded repetitions with a lower bound of 0 or 1 are equivalent to the repeat quantifiers
+        if node.Max == UNBOUNDED && node.Min <= 1 {
+            if node.Min == 0 {
+                g.expressionCFG(left, &RepeatNode { node.Expression, node.Start, node.End })
+            } else {
+                g.expressionCFG(left, &RepeatOneNode { node.Expression, node.Start, node.End })
+            }
+            return
+        }
+        // E_0 -> epsilon or E_1 -> E' (E_k generates exactly k occurrences of E')
+        // E_k -> E_{k - 1} E'
+        // E -> E_k (for all k between the bounds)
+        // E -> E E' (if there is no upper bound)
+        if t := g.expandExpressionCFG(left, node.Expression); t != nil {
+            high := node.Max; if high == UNBOUNDED { high = node.Min }
+            var previous NonTerminal
+            for k := min(node.Min, 1); k <= high; k++ {
+                next := g.deriveNonTerminal(left)
+                switch k {
+                case 0: g.productions = append(g.productions, &Production { NORMAL, next, []Symbol { }, "" })
+                case 1: g.productions = append(g.productions, &Production { NORMAL, next, []Symbol { t }, "" })
+                default: g.productions = append(g.productions, &Production { FLATTEN, next, []Symbol { previous, t }, "" })
+                }
+                if k >= node.Min { g.productions = append(g.productions, &Production { AUXILIARY, left, []Symbol { next }, "" }) }
+                previous = next
+            }
+            if node.Max == UNBOUNDED {
+                g.productions = append(g.productions, &Production { FLATTEN, left, []Symbol { left, t }, "" })
+            }
+        }
+    case *SeparatedNode:
+        // E' -> E' S E_0 (separator is dropped from the list)
+        // E' -> E_0
+        // E -> E' (if the list may be empty, E -> epsilon)
+        t, sep := g.expandExpressionCFG(left, node.Expression), g.expandExpressionCFG(left, node.Separator)
+        if t == nil || sep == nil { return }
+        list := left
+        if !node.NonEmpty { list = g.deriveNonTerminal(left) }
+        g.productions = append(g.productions,
+            &Production { FLATTEN, list, []Symbol { list, sep, t }, "" },
+            &Production { NORMAL,  list, []Symbol { t }, "" })
+        if !node.NonEmpty {
+            g.productions = append(g.productions,
+                &Production { AUXILIARY, left, []Symbol { list }, "" },
+                &Production { NORMAL,    left, []Symbol { }, "" })
+        }
     // New non-terminals are auxiliary when production is for a non-derived non-terminal
     case *ConcatNode: g.flattenConcatCFG(left, node, "")
     case *UnionNode:  g.flattenUnionCFG(left, node)
@@ -192,8 +352,9 @@ func (g *GrammarGenerator) flattenUnionCFG(left NonTerminal, node *UnionNode) {
     }
 }
 
-// For a given concatenated string of nodes from the AST, create production after generating list of symbols.
-func (g *GrammarGenerator) flattenConcatCFG(left NonTerminal, expression AST, visitor string) *Production {
+// For a given concatenated string of nodes from the AST, create productions after generating list of symbols.
+// A production is created for each combination of alternatives of the inline rules spliced into the sequence.
+func (g *GrammarGenerator) flattenConcatCFG(left NonTerminal, expression AST, visitor string) []*Production {
     // Flatten concatenated nodes to obtain symbols of each production
     var nodes []AST
     if n, ok := expression.(*ConcatNode); ok {
@@ -201,16 +362,93 @@ func (g *GrammarGenerator) flattenConcatCFG(left NonTerminal, expression AST, vi
     } else {
         nodes = []AST { expression }
     }
+    // Nodes shared between sequences are only expanded once, so that the productions do not conflict with each other
+    sequences, expanded := g.spliceInline(nodes), make(map[AST]Symbol)
+    productions := make([]*Production, len(sequences))
+    for i, nodes := range sequences { productions[i] = g.sequenceCFG(left, nodes, visitor, expanded) }
+    return productions
+}
+
+// Splices the expressions of inline rules referred to by a sequence of nodes into the sequence.
+// Returns a sequence for each combination of alternatives of the inline rules.
+func (g *GrammarGenerator) spliceInline(nodes []AST) [][]AST {
+    sequences := [][]AST { make([]AST, 0, len(nodes)) }
+    for _, node := range nodes {
+        rule, ok := g.inlineRule(node)
+        if !ok {
+            for i := range sequences { sequences[i] = append(sequences[i], node) }
+            continue
+        }
+        if rule == nil { continue }
+        // Find the sequences of each alternative, which may refer to other inline rules
+        var cases []AST
+        if n, ok := rule.Expression.(*UnionNode); ok { cases = flattenUnion(n, make([]AST, 0)) } else { cases = []AST { rule.Expression } }
+        alternatives := make([][]AST, 0, len(cases))
+        for _, c := range cases {
+            if n, ok := c.(*ConcatNode); ok {
+                alternatives = append(alternatives, g.spliceInline(flattenConcat(n, make([]AST, 0)))...)
+            } else {
+                alternatives = append(alternatives, g.spliceInline([]AST { c })...)
+            }
+        }
+        delete(g.inlining, rule.Identifier.Name)
+        // Extend every existing sequence with every alternative
+        next := make([][]AST, 0, len(sequences) * len(alternatives))
+        for _, s := range sequences {
+            for _, a := range alternatives { next = append(next, append(slices.Clone(s), a...)) }
+        }
+        sequences = next
+    }
+    return sequences
+}
+
+// Reports and removes the labels of the alternatives of an inline rule, since inline rules never generate nodes.
+func removeLabels(expression AST) AST {
+    switch n := expression.(type) {
+    case *UnionNode: return &UnionNode { removeLabels(n.A), removeLabels(n.B), n.Start, n.End }
+    case *LabelNode:
+        Error(fmt.Sprintf("Labels cannot be used in inline rules - %d:%d", n.Start.Line, n.Start.Col))
+        return n.Expression
+    }
+    return expression
+}
+
+// Finds the inline rule an expression refers to, and marks the rule as being expanded.
+// Returns a nil rule if the inline rule is already being expanded, since inline rules cannot be recursive.
+func (g *GrammarGenerator) inlineRule(expression AST) (*RuleNode, bool) {
+    id, ok := expression.(*IdentifierNode); if !ok { return nil, false }
+    rule, ok := g.inlines[id.Name]; if !ok { return nil, false }
+    if _, ok := g.inlining[id.Name]; ok {
+        Error(fmt.Sprintf("Inline rule \"%s\" cannot be recursive - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+        return nil, true
+    }
+    g.inlining[id.Name] = struct{}{}
+    return rule, true
+}
+
+// For a given sequence of nodes from the AST, create production after generating list of symbols.
+func (g *GrammarGenerator) sequenceCFG(left NonTerminal, nodes []AST, visitor string, expanded map[AST]Symbol) *Production {
     // Convert nodes in list to symbols
     symbols := make([]Symbol, 0, len(nodes))
     aliases, identifiers := make(map[string]int), make(map[string][]int)
+    dropped, hoist := make([]bool, len(nodes)), -1
     for i, node := range nodes {
+        // Intercept tree shaping annotations, string literals are also dropped if the grammar omits them
+        switch n := node.(type) {
+        case *DropNode: dropped[i] = true; node = n.Expression
+        case *HoistNode:
+            if hoist >= 0 { Error(fmt.Sprintf("Only one item of a production may be hoisted - %d:%d", n.Start.Line, n.Start.Col)) }
+            hoist = i; node = n.Expression
+        case *StringNode: dropped[i] = g.dropLiterals
+        }
         switch n := node.(type) {
         case *AliasNode:
             // Intercept aliases within concatenation expressions (not allowed elsewhere)
             // Build map between aliases and indices associated with the current production
             id := n.Identifier
-            if _, ok := aliases[id.Name]; !ok {
+            if dropped[i] {
+                Error(fmt.Sprintf("Dropped items cannot be aliased - %d:%d", id.Start.Line, id.Start.Col))
+            } else if _, ok := aliases[id.Name]; !ok {
                 aliases[id.Name] = i
             } else {
                 Error(fmt.Sprintf("Alias \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
@@ -222,18 +460,31 @@ func (g *GrammarGenerator) flattenConcatCFG(left NonTerminal, expression AST, vi
         case *OptionNode:    if id, ok := n.Expression.(*IdentifierNode); ok { identifiers[id.Name] = append(identifiers[id.Name], i) }
         case *RepeatNode:    if id, ok := n.Expression.(*IdentifierNode); ok { identifiers[id.Name] = append(identifiers[id.Name], i) }
         case *RepeatOneNode: if id, ok := n.Expression.(*IdentifierNode); ok { identifiers[id.Name] = append(identifiers[id.Name], i) }
+        case *RepeatRangeNode: if id, ok := n.Expression.(*IdentifierNode); ok { identifiers[id.Name] = append(identifiers[id.Name], i) }
+        case *SeparatedNode: if id, ok := n.Expression.(*IdentifierNode); ok { identifiers[id.Name] = append(identifiers[id.Name], i) }
+        // Template instantiations may be accessed by the template's identifier
+        case *TemplateNode: identifiers[n.Identifier.Name] = append(identifiers[n.Identifier.Name], i)
         }
-        symbols = append(symbols, g.expandExpressionCFG(left, node))
+        symbol, ok := expanded[node]
+        if !ok { symbol = g.expandExpressionCFG(left, node); expanded[node] = symbol }
+        symbols = append(symbols, symbol)
     }
     // If an identifier has only one occurrence and no explicit alias of the same name exists, add as implicit alias
     for id, indices := range identifiers {
-        if _, ok := aliases[id]; ok || len(indices) > 1 { continue }
+        if _, ok := aliases[id]; ok || len(indices) > 1 || dropped[indices[0]] { continue }
         aliases[id] = indices[0]
     }
     // Create production struct
     // If alias map contains entries, create association between it and production
     production := &Production { NORMAL, left, symbols, visitor }
     g.productions = append(g.productions, production)
+    if slices.Contains(dropped, true) || hoist >= 0 {
+        // Alias indices refer to the children that remain after dropped children are removed
+        for id, i := range aliases {
+            for _, d := range dropped[:i] { if d { aliases[id]-- } }
+        }
+        g.shapes[production] = Shape { dropped, hoist }
+    }
     if len(aliases) > 0 { g.aliasMaps[production] = aliases }
     return production
 }
@@ -245,6 +496,10 @@ func (g *GrammarGenerator) literalCFG(expression AST) (Symbol, bool) {
         // Finds the terminal or non-terminal that the identifier is referring to
         if _, ok := g.terminals[node.Name];      ok { return Terminal(node.Name), true }
         if _, ok := g.nonTerminalMap[node.Name]; ok { return NonTerminal(node.Name), true }
+        if t, ok := g.templates[node.Name]; ok {
+            Error(fmt.Sprintf("Template \"%s\" requires %d arguments - %d:%d", node.Name, len(t.Parameters), node.Start.Line, node.Start.Col))
+            return nil, true
+        }
         Error(fmt.Sprintf("Identifier \"%s\" is not defined - %d:%d", node.Name, node.Start.Line, node.Start.Col))
     case *StringNode:
         // Find terminal associated with a string, resolves if explicit string definition exists in AST
@@ -252,46 +507,93 @@ func (g *GrammarGenerator) literalCFG(expression AST) (Symbol, bool) {
         if t, ok := g.strings[str]; ok { return t, true }
         Error(fmt.Sprintf("No token explicitly matches \"%s\" - %d:%d", str, node.Start.Line, node.Start.Col))
     case *ErrorNode: return Terminal(ERROR_TERMINAL), true
+    case *TemplateNode: return g.instantiate(node), true
     case *ClassNode: Error(fmt.Sprintf("Classes cannot be used in rule expressions - %d:%d", node.Start.Line, node.Start.Col))
     case *LabelNode: Error(fmt.Sprintf("Invalid use of label - %d:%d", node.Start.Line, node.Start.Col))
     case *AliasNode: Error(fmt.Sprintf("Invalid use of alias - %d:%d", node.Start.Line, node.Start.Col))
+    case *DropNode:  Error(fmt.Sprintf("Invalid use of drop annotation - %d:%d", node.Start.Line, node.Start.Col))
+    case *HoistNode: Error(fmt.Sprintf("Invalid use of hoist annotation - %d:%d", node.Start.Line, node.Start.Col))
+    case *DifferenceNode:   Error(fmt.Sprintf("Class operations cannot be used in rule expressions - %d:%d", node.Start.Line, node.Start.Col))
+    case *IntersectionNode: Error(fmt.Sprintf("Class operations cannot be used in rule expressions - %d:%d", node.Start.Line, node.Start.Col))
     default: return nil, false
     }
     return nil, true
 }
 
-// Removes simple precedence and associativity operator-form ambiguities in the grammar.
-// Not guaranteed to remove all ambiguities, but will resolve those of infix, prefix, and postfix operations.
-func (g *GrammarGenerator) removeAmbiguities(nodes []*PrecedenceNode) {
-    type AmbiguityType uint
-    const (INFIX AmbiguityType = iota; PREFIX; POSTFIX)
-    type Ambiguity struct { ambiguityType AmbiguityType; production *Production }
-    // Read precedence declarations in grammar
-    precedence, associativity := make(map[string]int), make([]AssociativityType, 0)
+// Converts a rule template to a non-terminal specialized for the arguments of the given instantiation.
+// Instantiations with identical arguments share the same non-terminal.
+func (g *GrammarGenerator) instantiate(node *TemplateNode) Symbol {
+    id := node.Identifier
+    template, ok := g.templates[id.Name]
+    if !ok {
+        Error(fmt.Sprintf("Template \"%s\" is not defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+        return nil
+    }
+    if len(node.Arguments) != len(template.Parameters) {
+        Error(fmt.Sprintf("Template \"%s\" requires %d arguments but %d were given - %d:%d",
+            id.Name, len(template.Parameters), len(node.Arguments), id.Start.Line, id.Start.Col))
+        return nil
+    }
+    key := node.String()
+    if t, ok := g.instances[key]; ok { return t }
+    if g.depth >= MAX_TEMPLATE_DEPTH {
+        Error(fmt.Sprintf("Template \"%s\" exceeds maximum instantiation depth - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+        return nil
+    }
+    // Register non-terminal before expanding so recursive instantiations refer to it
+    t := g.deriveNonTerminal(NonTerminal(id.Name))
+    g.instances[key] = t
+    // Replace parameters with arguments, productions without labels are visited using the template's name
+    arguments := make(map[string]AST, len(template.Parameters))
+    for i, p := range template.Parameters { arguments[p.Name] = node.Arguments[i] }
+    g.depth++
+    g.flattenProductions(t, substitute(template.Expression, arguments), id.Name)
+    g.depth--
+    return t
+}
+
+// Reads precedence declarations in grammar, precedence levels are ordered from lowest to highest.
+func (g *GrammarGenerator) readPrecedence(nodes []*PrecedenceNode) {
+    g.precedence, g.associativity = make(map[string]int), make([]AssociativityType, 0)
     for _, p := range nodes {
         id := p.Identifier
-        if _, ok := precedence[id.Name]; ok {
+        if _, ok := g.precedence[id.Name]; ok {
             Error(fmt.Sprintf("Precedence \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
             continue
         }
-        i := len(precedence); precedence[id.Name] = i
-        associativity = append(associativity, p.Associativity)
+        i := len(g.precedence); g.precedence[id.Name] = i
+        g.associativity = append(g.associativity, p.Associativity)
     }
+}
+
+// Removes simple precedence and associativity operator-form ambiguities in the grammar.
+// Not guaranteed to remove all ambiguities, but will resolve those of infix, prefix, and postfix operations.
+func (g *GrammarGenerator) removeAmbiguities() {
+    type AmbiguityType uint
+    const (INFIX AmbiguityType = iota; PREFIX; POSTFIX)
+    type Ambiguity struct { ambiguityType AmbiguityType; production *Production }
+    precedence, associativity := g.precedence, g.associativity
     // Group productions together based on their non-terminal
-    productions := make(map[NonTerminal][]*Production, len(g.nonTerminals))
-    for _, p := range g.productions { productions[p.Left] = append(productions[p.Left], p) }
-    for nt, group := range productions {
+    // Groups are processed in order of first appearance so derived non-terminals and productions are created in a fixed order
+    productions, order := make(map[NonTerminal][]*Production, len(g.nonTerminals)), make([]NonTerminal, 0, len(g.nonTerminals))
+    for _, p := range g.productions {
+        if _, ok := productions[p.Left]; !ok { order = append(order, p.Left) }
+        productions[p.Left] = append(productions[p.Left], p)
+    }
+    for _, nt := range order {
+        group := productions[nt]
         // Split productions for a given non-terminal based on if there exists a precedence label (and sort)
         // Determine ambiguity type based on explicit left or right-recursion
         a, rest := make([][]Ambiguity, len(precedence)), make([]*Production, 0)
         for _, p := range group {
             if label, ok := g.labels[p]; ok && label.Precedence != nil {
-                l, r := p.Right[0] == nt, p.Right[len(p.Right) - 1] == nt
-                i, ok := precedence[label.Precedence.Name]; assoc := associativity[i]
+                l, r := len(p.Right) > 0 && p.Right[0] == nt, len(p.Right) > 0 && p.Right[len(p.Right) - 1] == nt
+                i, ok := precedence[label.Precedence.Name]
                 if !ok {
                     Error(fmt.Sprintf("Precedence label \"%s\" is not defined - %d:%d", label.Precedence.Name, label.Start.Line, label.Start.Col))
                     continue
                 }
+                assoc := associativity[i]
                 // Determine type of recursion and type of operator
                 switch {
                 case l && r: // E -> E ... E
@@ -303,7 +605,10 @@ func (g *GrammarGenerator) removeAmbiguities(nodes []*PrecedenceNode) {
                 case r: // E -> ... E
                     a[i] = append(a[i], Ambiguity { PREFIX, p })
                     if assoc == NO_ASSOC { continue }
-                default: rest = append(rest, p)
+                default:
+                    // Labels of non-recursive productions only determine the precedence used to resolve parse table conflicts
+                    rest = append(rest, p)
+                    continue
                 }
                 Error(fmt.Sprintf("Precedence label cannot be used for current production - %d:%d",
                     label.Start.Line, label.Start.Col))
@@ -336,10 +641,10 @@ func (g *GrammarGenerator) removeAmbiguities(nodes []*PrecedenceNode) {
                     // For infix ambiguity, eliminate either left or right-recursion to force associativity
                     // For left-associative productions,  E_k -> E_k ... E_{k + 1} (eliminate right-recursion)
                     // For right-associative productions, E_k -> E_{k + 1} ... E_k
-                    if associativity[i] == RIGHT_ASSOC {
-                        right[0], right[len(right) - 1] = next, left
-                    } else {
-                        right[0], right[len(right) - 1] = left, next
+                    // Non-associative productions are rewritten like left-associative productions, the parse table rejects chained operations
+                    switch associativity[i] {
+                    case RIGHT_ASSOC: right[0], right[len(right) - 1] = next, left
+                    default:          right[0], right[len(right) - 1] = left, next
                     }
                 case PREFIX:  right[len(right) - 1] = left
                 case POSTFIX: right[0] = left
@@ -355,6 +660,67 @@ func (g *GrammarGenerator) removeAmbiguities(nodes []*PrecedenceNode) {
     }
 }
 
+// Assigns precedence levels to the tokens listed in precedence statements and to productions.
+// Productions with a precedence label take the precedence of the label, otherwise that of their last terminal with a precedence level.
+func (g *GrammarGenerator) assignPrecedence(nodes []*PrecedenceNode) (map[Terminal]PrecedenceLevel, map[*Production]PrecedenceLevel) {
+    terminals := make(map[Terminal]PrecedenceLevel)
+    for _, p := range nodes {
+        i, ok := g.precedence[p.Identifier.Name]
+        if !ok { continue }
+        level := PrecedenceLevel { i, g.associativity[i], p.Identifier.Name }
+        for _, token := range p.Tokens {
+            symbol, _ := g.literalCFG(token)
+            if symbol == nil { continue }
+            var start parser.Location
+            switch n := token.(type) {
+            case *IdentifierNode: start = n.Start
+            case *StringNode:     start = n.Start
+            }
+            t, ok := symbol.(Terminal)
+            if !ok {
+                Error(fmt.Sprintf("Precedence can only be assigned to tokens - %d:%d", start.Line, start.Col))
+                continue
+            }
+            if _, ok := terminals[t]; ok {
+                Error(fmt.Sprintf("Precedence of token \"%s\" is already defined - %d:%d", t, start.Line, start.Col))
+                continue
+            }
+            terminals[t] = level
+        }
+    }
+    productions := make(map[*Production]PrecedenceLevel)
+    for _, p := range g.productions {
+        if label, ok := g.labels[p]; ok && label.Precedence != nil {
+            if i, ok := g.precedence[label.Precedence.Name]; ok { productions[p] = PrecedenceLevel { i, g.associativity[i], label.Precedence.Name } }
+            continue
+        }
+        for i := len(p.Right) - 1; i >= 0; i-- {
+            if t, ok := p.Right[i].(Terminal); ok {
+                if level, ok := terminals[t]; ok { productions[p] = level; break }
+            }
+        }
+    }
+    return terminals, productions
+}
+
+// Returns whether the child generated by a non-terminal is always a node, following auxiliary and hoisted children.
+func (g *GrammarGenerator) derivesNode(t NonTerminal, visited map[NonTerminal]struct{}) bool {
+    if _, ok := visited[t]; ok { return true }
+    visited[t] = struct{}{}
+    for _, p := range g.productions {
+        if p.Left != t { continue }
+        var next Symbol
+        switch p.Type {
+        case AUXILIARY: next = p.Right[0]
+        case REMOVED:   return false
+        case NORMAL:    if shape, ok := g.shapes[p]; ok && shape.Hoist >= 0 { next = p.Right[shape.Hoist] }
+        }
+        if next == nil { continue }
+        if nt, ok := next.(NonTerminal); !ok || !g.derivesNode(nt, visited) { return false }
+    }
+    return true
+}
+
 // Creates a new non-terminal derived from a parent non-terminal.
 func (g *GrammarGenerator) deriveNonTerminal(nt NonTerminal) NonTerminal {
     // Derive new non-terminal from parent
@@ -374,17 +740,50 @@ func (g *GrammarGenerator) deriveNonTerminal(nt NonTerminal) NonTerminal {
     return t
 }
 
-// Augment grammar with new start state. Returns production for augmented start state.
-func (g *Grammar) Augment() *Production {
-    t := NonTerminal("S'")
-    production := &Production { NORMAL, t, []Symbol { g.Start }, "" }
-    g.NonTerminals = append(g.NonTerminals, t)
-    g.Productions = append(g.Productions, production)
-    return production
+// Augment grammar with new start states for the start non-terminal and each entry non-terminal.
+// Returns productions for augmented start states, beginning with the production for the start non-terminal.
+func (g *Grammar) Augment() []*Production {
+    starts := []NonTerminal { g.Start }
+    for _, t := range g.Entries {
+        if t != g.Start { starts = append(starts, t) }
+    }
+    augmented := make([]*Production, len(starts))
+    for i, start := range starts {
+        t := NonTerminal("S'")
+        if i > 0 { t = NonTerminal(fmt.Sprintf("%s'", start)) }
+        augmented[i] = &Production { NORMAL, t, []Symbol { start }, "" }
+        g.NonTerminals = append(g.NonTerminals, t)
+        g.Productions = append(g.Productions, augmented[i])
+    }
+    return augmented
 }
 
 // ------------------------------------------------------------------------------------------------------------------------------
 
+func substitute(expression AST, arguments map[string]AST) AST {
+    // Copy expression tree, replacing identifiers that refer to parameters
+    switch node := expression.(type) {
+    case *IdentifierNode: if a, ok := arguments[node.Name]; ok { return a }
+    case *OptionNode:      return &OptionNode    { substitute(node.Expression, arguments), node.Start, node.End }
+    case *RepeatNode:      return &RepeatNode    { substitute(node.Expression, arguments), node.Start, node.End }
+    case *RepeatOneNode:   return &RepeatOneNode { substitute(node.Expression, arguments), node.Start, node.End }
+    case *RepeatRangeNode: return &RepeatRangeNode { substitute(node.Expression, arguments), node.Min, node.Max, node.Start, node.End }
+    case *SeparatedNode:
+        return &SeparatedNode { substitute(node.Expression, arguments), substitute(node.Separator, arguments), node.NonEmpty, node.Start, node.End }
+    case *LabelNode:       return &LabelNode { substitute(node.Expression, arguments), node.Identifier, node.Precedence, node.Start, node.End }
+    case *AliasNode:       return &AliasNode { node.Identifier, substitute(node.Expression, arguments), node.Start, node.End }
+    case *DropNode:        return &DropNode  { substitute(node.Expression, arguments), node.Start, node.End }
+    case *HoistNode:       return &HoistNode { substitute(node.Expression, arguments), node.Start, node.End }
+    case *ConcatNode:      return &ConcatNode { substitute(node.A, arguments), substitute(node.B, arguments), node.Start, node.End }
+    case *UnionNode:       return &UnionNode  { substitute(node.A, arguments), substitute(node.B, arguments), node.Start, node.End }
+    case *TemplateNode:
+        substituted := make([]AST, len(node.Arguments))
+        for i, a := range node.Arguments { substituted[i] = substitute(a, arguments) }
+        return &TemplateNode { node.Identifier, substituted, node.Start, node.End }
+    }
+    return expression
+}
+
 func flattenConcat(node *ConcatNode, nodes []AST) []AST {
     if a, ok := node.A.(*ConcatNode); ok { nodes = flattenConcat(a, nodes) } else { nodes = append(nodes, node.A) }
     if b, ok := node.B.(*ConcatNode); ok { nodes = flattenConcat(b, nodes) } else { nodes = append(nodes, node.B) }
@@ -415,6 +814,7 @@ func (p Production)  String() string {
 // Prints all production rules of the grammar.
 func (g *Grammar) PrintGrammar() {
     fmt.Printf("[start: %s]\n", g.Start)
+    for _, t := range g.Entries { fmt.Printf("[entry: %s]\n", t) }
     for _, production := range g.Productions {
         var str string
         switch production.Type {
@@ -3,9 +3,12 @@ package lynn
 import (
 	"fmt"
 	"lynn/lynn/parser"
+	"maps"
 	"regexp"
 	"slices"
 	"sort"
+	"strings"
+	"unicode"
 	"unsafe"
 )
 
@@ -34,16 +37,22 @@ type LDFAState = DFAState[parser.Range]
 // Lexer generator struct. Converts token definitions in abstract syntax tree (AST) to finite automata (FA).
 type LexerGenerator struct {
     fragments map[string]LNFAFragment	
+    classes   map[string][]parser.Range // Fragments that only match single characters
     ranges    map[parser.Range]struct{}
     accept    map[*LDFAState]string
+    tokens    map[string]*TokenNode          // Token declarations, used to locate shadowed tokens
+    shadows   map[string]map[string]struct{} // Tokens accepted in place of each token
+    loader    *GrammarLoader                 // Loader of the grammar, used to find the files of declarations
 }
 
 // Returns a new lexer generator struct.
-func NewLexerGenerator() *LexerGenerator { return &LexerGenerator { } }
-// Converts regular expressions defined in grammar into a non-deterministic finite automata.
-func (g *LexerGenerator) GenerateNFA(grammar *GrammarNode) (LNFA, []parser.Range) {
-    g.fragments, g.ranges = make(map[string]LNFAFragment), make(map[parser.Range]struct{})
+func NewLexerGenerator(loader *GrammarLoader) *LexerGenerator { return &LexerGenerator { loader: loader } }
+// Converts regular expressions defined in grammar into non-deterministic finite automata.
+// One automata is generated for each lexer mode, in the order the modes are listed in the grammar.
+func (g *LexerGenerator) GenerateNFA(grammar *GrammarNode) ([]LNFA, []parser.Range) {
+    g.fragments, g.classes, g.ranges = make(map[string]LNFAFragment), make(map[string][]parser.Range), make(map[parser.Range]struct{})
     tokens := make(map[string]struct{}, len(grammar.Fragments) + len(grammar.Tokens))
+    g.tokens = make(map[string]*TokenNode, len(grammar.Tokens))
     for _, fragment := range grammar.Fragments {
         // Convert fragment expressions to NFAs and add fragment to identifier map
         nfa, ok := g.expressionNFA(fragment.Expression)
@@ -52,13 +61,24 @@ func (g *LexerGenerator) GenerateNFA(grammar *GrammarNode) (LNFA, []parser.Range
             Error(fmt.Sprintf("Fragment \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
         } else if ok {
             g.fragments[id.Name] = nfa
+            // Fragments that reduce to character sets may be used as operands of class operations
+            if ranges, ok := g.classRanges(fragment.Expression); ok { g.classes[id.Name] = ranges }
             tokens[id.Name] = struct{}{} // Register fragment name as used so token names don't overlap
         }
     }
+    // Create map from mode names to their indices and initialize an NFA for each mode
+    modes, nfa := make(map[string]int, len(grammar.Modes)), make([]LNFA, len(grammar.Modes))
+    for i, mode := range grammar.Modes {
+        nfa[i] = LNFA { &LNFAState { make(map[parser.Range]*LNFAState, 0), make([]*LNFAState, 0) }, make(map[*LNFAState]LNFAAccept) }
+        id := mode.Identifier
+        if _, ok := modes[id.Name]; ok {
+            Error(fmt.Sprintf("Mode \"%s\" is already defined - %d:%d", id.Name, id.Start.Line, id.Start.Col))
+            continue
+        }
+        modes[id.Name] = i
+    }
     // If EOF is not defined, add default token to list
     injectEOF(grammar)
-    start := &LNFAState { make(map[parser.Range]*LNFAState, 0), make([]*LNFAState, 0, len(grammar.Tokens)) }
-    accept := make(map[*LNFAState]LNFAAccept, len(grammar.Tokens))
     for i, token := range grammar.Tokens {
         // Convert token expressions to NFAs and attach fragment to final NFA
         id := token.Identifier
@@ -67,22 +87,37 @@ func (g *LexerGenerator) GenerateNFA(grammar *GrammarNode) (LNFA, []parser.Range
             continue
         }
         tokens[id.Name] = struct{}{}
+        g.tokens[id.Name] = token
+        // Ensure mode action refers to a defined mode
+        if action := token.Action; action != nil && action.Mode != nil {
+            if _, ok := modes[action.Mode.Name]; !ok {
+                Error(fmt.Sprintf("Mode \"%s\" is not defined - %d:%d", action.Mode.Name, action.Mode.Start.Line, action.Mode.Start.Col))
+            }
+        }
         if token.Expression == nil { continue }
-        nfa, ok := g.expressionNFA(token.Expression)
+        fragment, ok := g.expressionNFA(token.Expression)
         if ok {
             // Invalid if accept node can be reached from the start node through only epsilon transitions
-            if isAccessible(nfa.In, nfa.Out, make(map[*LNFAState]struct{})) {
+            if isAccessible(fragment.In, fragment.Out, make(map[*LNFAState]struct{})) {
                 Error(fmt.Sprintf("Invalid regular expression for token \"%s\" - %d:%d", id.Name, id.Start.Line, id.Start.Col))
                 continue
             }
-            start.AddEpsilon(nfa.In)
-            accept[nfa.Out] = LNFAAccept { id.Name, i }
+            // For case-insensitive tokens, extend all transitions to include case-folded characters
+            if token.NoCase { fragment.In.foldCase(g.ranges, make(map[*LNFAState]struct{})) }
+            // The EOF token must be recognized in every mode, otherwise attach to the NFA of the token's mode
+            targets := nfa[modes[token.Mode]:modes[token.Mode] + 1]
+            if id.Name == EOF_TERMINAL { targets = nfa }
+            for _, n := range targets {
+                n.Start.AddEpsilon(fragment.In)
+                n.Accept[fragment.Out] = LNFAAccept { id.Name, i }
+            }
         }
     }
     // Disjoin all occurring ranges and apply to transitions in NFA
     ranges, expansion := disjoinRanges(g.ranges)
-    start.expand(expansion, make(map[*LNFAState]struct{}))
-    return LNFA { start, accept }, ranges
+    visited := make(map[*LNFAState]struct{})
+    for _, n := range nfa { n.Start.expand(expansion, visited) }
+    return nfa, ranges
 }
 
 func injectEOF(grammar *GrammarNode) {
@@ -95,6 +130,7 @@ func injectEOF(grammar *GrammarNode) {
         Identifier: &IdentifierNode { Name: EOF_TERMINAL },
         Expression: &StringNode { Chars: []rune { 0 } },
         Skip: false,
+        Mode: DEFAULT_MODE,
     })
 }
 
@@ -120,6 +156,8 @@ func (g *LexerGenerator) expressionNFA(expression AST) (LNFAFragment, bool) {
         in := &LNFAState { make(map[parser.Range]*LNFAState, 0), []*LNFAState { nfa.In } }
         nfa.Out.AddEpsilon(nfa.In, out)
         return LNFAFragment { in, out }, true
+    case *RepeatRangeNode: return g.expressionNFA(expandRepetition(node))
+    case *SeparatedNode:   return g.expressionNFA(expandSeparated(node))
 
     case *ConcatNode:
         a, ok := g.expressionNFA(node.A); if !ok { return a, ok }
@@ -128,6 +166,8 @@ func (g *LexerGenerator) expressionNFA(expression AST) (LNFAFragment, bool) {
         a.Out.AddEpsilon(b.In)
         return LNFAFragment { a.In, b.Out }, true
     case *UnionNode:
+        // Unions of character sets are folded into a single class
+        if ranges, ok := g.classRanges(node); ok { return g.expressionNFA(&ClassNode { ranges, node.Start, node.End }) }
         a, ok := g.expressionNFA(node.A); if !ok { return a, ok }
         b, ok := g.expressionNFA(node.B); if !ok { return b, ok }
         // Create in state with epsilon transitions to in states of both fragments
@@ -136,6 +176,8 @@ func (g *LexerGenerator) expressionNFA(expression AST) (LNFAFragment, bool) {
         a.Out.AddEpsilon(out) // Create epsilon transitions from out states of fragments to final out state
         b.Out.AddEpsilon(out)
         return LNFAFragment { in, out }, true
+    case *DifferenceNode:   return g.classOperationNFA(node, node.Start, node.End)
+    case *IntersectionNode: return g.classOperationNFA(node, node.Start, node.End)
 
     // Generate NFAs for literals
     case *IdentifierNode:
@@ -157,8 +199,14 @@ func (g *LexerGenerator) expressionNFA(expression AST) (LNFAFragment, bool) {
         state := out
         for i := len(node.Chars) - 1; i >= 0; i-- {
             char := node.Chars[i]
-            r := parser.Range { Min: char, Max: char }; g.ranges[r] = struct{}{}
-            state = &LNFAState { map[parser.Range]*LNFAState { r: state }, make([]*LNFAState, 0) }
+            r := parser.Range { Min: char, Max: char }
+            next := &LNFAState { map[parser.Range]*LNFAState { r: state }, make([]*LNFAState, 0) }
+            g.ranges[r] = struct{}{}
+            // Case-insensitive strings also transition on all case-folded equivalents of each character
+            if node.Insensitive {
+                for _, r := range foldRange(r) { next.Transitions[r] = state; g.ranges[r] = struct{}{} }
+            }
+            state = next
         }
         return LNFAFragment { state, out }, true
     case *ClassNode:
@@ -180,6 +228,15 @@ func (g *LexerGenerator) expressionNFA(expression AST) (LNFAFragment, bool) {
     case *AliasNode:
         Error(fmt.Sprintf("Aliases cannot be used in token expressions - %d:%d", node.Start.Line, node.Start.Col))
         return LNFAFragment { }, false
+    case *DropNode:
+        Error(fmt.Sprintf("Drop annotations cannot be used in token expressions - %d:%d", node.Start.Line, node.Start.Col))
+        return LNFAFragment { }, false
+    case *HoistNode:
+        Error(fmt.Sprintf("Hoist annotations cannot be used in token expressions - %d:%d", node.Start.Line, node.Start.Col))
+        return LNFAFragment { }, false
+    case *TemplateNode:
+        Error(fmt.Sprintf("Templates cannot be used in token expressions - %d:%d", node.Start.Line, node.Start.Col))
+        return LNFAFragment { }, false
     default: panic("Invalid expression passed to LexerGenerator.expressionNFA()")
     }
 }
@@ -195,6 +252,90 @@ func isAccessible(state *LNFAState, target *LNFAState, visited map[*LNFAState]st
     return false
 }
 
+// Converts a class operation to an NFA fragment by folding it into a single class.
+func (g *LexerGenerator) classOperationNFA(node AST, start, end parser.Location) (LNFAFragment, bool) {
+    ranges, ok := g.classRanges(node)
+    if !ok {
+        Error(fmt.Sprintf("Operands of class operations must only match single characters - %d:%d", start.Line, start.Col))
+        return LNFAFragment { }, false
+    }
+    if len(ranges) == 0 {
+        Error(fmt.Sprintf("Class operation does not match any characters - %d:%d", start.Line, start.Col))
+        return LNFAFragment { }, false
+    }
+    return g.expressionNFA(&ClassNode { ranges, start, end })
+}
+
+// Reduces an expression to the set of characters it matches.
+// Returns false if the expression is able to match a sequence of multiple characters.
+func (g *LexerGenerator) classRanges(expression AST) ([]parser.Range, bool) {
+    switch node := expression.(type) {
+    case *ClassNode: return node.Ranges, true
+    case *StringNode:
+        if len(node.Chars) != 1 { return nil, false }
+        r := parser.Range { Min: node.Chars[0], Max: node.Chars[0] }
+        if node.Insensitive { return foldRange(r), true }
+        return []parser.Range { r }, true
+    case *IdentifierNode:
+        ranges, ok := g.classes[node.Name]
+        return ranges, ok
+    case *UnionNode:
+        a, b, ok := g.operandRanges(node.A, node.B); if !ok { return nil, false }
+        return unionRanges(a, b), true
+    case *DifferenceNode:
+        a, b, ok := g.operandRanges(node.A, node.B); if !ok { return nil, false }
+        return subtractRanges(a, b), true
+    case *IntersectionNode:
+        a, b, ok := g.operandRanges(node.A, node.B); if !ok { return nil, false }
+        return intersectRanges(a, b), true
+    }
+    return nil, false
+}
+
+func (g *LexerGenerator) operandRanges(a, b AST) ([]parser.Range, []parser.Range, bool) {
+    left, ok := g.classRanges(a); if !ok { return nil, nil, false }
+    right, ok := g.classRanges(b)
+    return left, right, ok
+}
+
+// Finds all characters equivalent to those in a range under Unicode case folding.
+// Returns the set of ranges covering the original range and all of its case-folded characters.
+func foldRange(r parser.Range) []parser.Range {
+    ranges := []parser.Range { r }
+    for c := r.Min; c <= r.Max; c++ {
+        // Iterate through orbit of equivalent characters
+        for f := unicode.SimpleFold(c); f != c; f = unicode.SimpleFold(f) {
+            if f < r.Min || f > r.Max { ranges = append(ranges, parser.Range { Min: f, Max: f }) }
+        }
+    }
+    return mergeRanges(ranges)
+}
+
+// Expands a bounded repeat quantifier to an equivalent concatenation of the required occurrences followed by the
+// optional occurrences. For example: E{2,4} -> E E E? E?, E{2,} -> E E E*
+func expandRepetition(node *RepeatRangeNode) AST {
+    var expanded AST
+    add := func (n AST) {
+        if expanded == nil { expanded = n; return }
+        expanded = &ConcatNode { expanded, n, node.Start, node.End }
+    }
+    for range node.Min { add(node.Expression) }
+    if node.Max == UNBOUNDED {
+        add(&RepeatNode { node.Expression, node.Start, node.End })
+    } else {
+        for range node.Max - node.Min { add(&OptionNode { node.Expression, node.Start, node.End }) }
+    }
+    return expanded
+}
+
+// Converts a separated list to an equivalent expression using repetition, E % S is equivalent to (E (S E)*)?
+func expandSeparated(node *SeparatedNode) AST {
+    rest := &RepeatNode { &ConcatNode { node.Separator, node.Expression, node.Start, node.End }, node.Start, node.End }
+    var expanded AST = &ConcatNode { node.Expression, rest, node.Start, node.End }
+    if !node.NonEmpty { expanded = &OptionNode { expanded, node.Start, node.End } }
+    return expanded
+}
+
 // This function converts a set of ranges to a one that is mutually disjoint and has the same union.
 // This operates by splitting the original ranges rather than merging them. Final output is a map from
 // the original range to its corresponding set of disjoined ranges.
@@ -264,11 +405,13 @@ func createExpansionMap(ranges map[parser.Range]struct{}, disjoined []parser.Ran
 // ------------------------------------------------------------------------------------------------------------------------------
 
 // Converts non-deterministic finite automata to deterministic finite automata.
+// Reports tokens that are never accepted because every string they match is also matched by a higher priority token.
 func (g *LexerGenerator) NFAtoDFA(nfa LNFA, ranges []parser.Range) LDFA {
     subsets := make(map[string]*LDFAState)
-    g.accept = make(map[*LDFAState]string, len(nfa.Accept))
+    g.accept, g.shadows = make(map[*LDFAState]string, len(nfa.Accept)), make(map[string]map[string]struct{})
     // Convert NFA to initial DFA through power-set construction
     start := g.mergeTransitions([]*LNFAState { nfa.Start }, nfa, subsets)
+    g.reportShadowed(nfa)
     // Extract all DFA states from subset map
     states := make([]*LDFAState, 0, len(subsets))
     for _, state := range subsets { states = append(states, state) }
@@ -301,7 +444,15 @@ func (g *LexerGenerator) mergeTransitions(states []*LNFAState, nfa LNFA, subsets
     // Create and store DFA state
     state := &LDFAState{ make(map[parser.Range]*LDFAState, len(merged)) }
     subsets[key] = state
-    if id, ok := nfa.resolveAccept(closure); ok { g.accept[state] = id }
+    if id, ok := nfa.resolveAccept(closure); ok {
+        g.accept[state] = id
+        // Record the accepted token for all other tokens that could be accepted by the state
+        for s := range closure {
+            a, ok := nfa.Accept[s]; if !ok || a.Identifier == id { continue }
+            if g.shadows[a.Identifier] == nil { g.shadows[a.Identifier] = make(map[string]struct{}) }
+            g.shadows[a.Identifier][id] = struct{}{}
+        }
+    }
     // Convert each subset that this state may transition into its own DFA state recursively
     for value, states := range merged {
         state.Transitions[value] = g.mergeTransitions(states, nfa, subsets)
@@ -309,6 +460,30 @@ func (g *LexerGenerator) mergeTransitions(states []*LNFAState, nfa LNFA, subsets
     return state
 }
 
+// Reports tokens of an NFA that are not accepted by any DFA state, along with the tokens accepted in their place.
+func (g *LexerGenerator) reportShadowed(nfa LNFA) {
+    // Tokens that are accepted by at least one DFA state
+    accepted := make(map[string]struct{}, len(g.accept))
+    for _, id := range g.accept { accepted[id] = struct{}{} }
+    priorities := make(map[string]int, len(nfa.Accept))
+    for _, a := range nfa.Accept { priorities[a.Identifier] = a.Priority }
+    ids := slices.SortedFunc(maps.Keys(priorities), func (a, b string) int { return priorities[a] - priorities[b] })
+    for _, id := range ids {
+        if _, ok := accepted[id]; ok { continue }
+        // List tokens that shadow the token in order of priority
+        shadows := slices.SortedFunc(maps.Keys(g.shadows[id]), func (a, b string) int { return priorities[a] - priorities[b] })
+        names := make([]string, len(shadows))
+        for i, s := range shadows { names[i] = fmt.Sprintf("\"%s\"", s) }
+        location := g.loader.location(g.tokens[id].Identifier)
+        switch len(names) {
+        case 0: Warning(fmt.Sprintf("Token \"%s\" does not match any string - %s", id, location))
+        case 1: Warning(fmt.Sprintf("Token \"%s\" is shadowed by token %s declared before it - %s", id, names[0], location))
+        default:
+            Warning(fmt.Sprintf("Token \"%s\" is shadowed by tokens %s declared before it - %s", id, strings.Join(names, ", "), location))
+        }
+    }
+}
+
 // Finds set of states reachable from given state through only epsilon transitions.
 func epsilonClosure(state *LNFAState, closure map[*LNFAState]struct{}) {
     if _, ok := closure[state]; ok { return }
@@ -363,6 +538,20 @@ func (s *LNFAState) copy(copied map[*LNFAState]*LNFAState) *LNFAState {
     return copy
 }
 
+// Adds case-folded equivalents of the transitions of an NFA state and all other states reachable from it.
+func (s *LNFAState) foldCase(ranges map[parser.Range]struct{}, visited map[*LNFAState]struct{}) {
+    // If state has already been folded, exit
+    if _, ok := visited[s]; ok { return }
+    visited[s] = struct{}{}
+    folded := make(map[parser.Range]*LNFAState, len(s.Transitions))
+    for value, state := range s.Transitions {
+        for _, r := range foldRange(value) { folded[r] = state; ranges[r] = struct{}{} }
+        state.foldCase(ranges, visited)
+    }
+    for _, state := range s.Epsilon { state.foldCase(ranges, visited) }
+    s.Transitions = folded
+}
+
 // Duplicate transitions based on expansion map created by createExpansionMap().
 func (s *LNFAState) expand(expansion map[parser.Range][]parser.Range, visited map[*LNFAState]struct{}) {
     // If state has already been expanded, exit
@@ -0,0 +1,127 @@
+package lynn
+
+import (
+	"fmt"
+	"lynn/lynn/parser"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Grammar loader struct. Parses grammar definition files and merges imported files into a single AST.
+type GrammarLoader struct {
+    stack  []string                   // Files currently being loaded, used to detect import cycles
+    loaded map[string]struct{}        // Files that have already been loaded, each file is only loaded once
+    files  map[*IdentifierNode]string // Files that each declaration originates from
+}
+
+// Returns a new grammar loader struct.
+func NewGrammarLoader() *GrammarLoader { return &GrammarLoader { } }
+// Parses grammar definition file at the given path and all files it imports.
+// Imported declarations are listed in place of their import statement, so declaration order is preserved across files.
+// Returns nil if an error occurs while loading any file.
+func (l *GrammarLoader) Load(path string) *GrammarNode {
+    l.stack, l.loaded, l.files = make([]string, 0), make(map[string]struct{}), make(map[*IdentifierNode]string)
+    grammar := l.load(path, "", parser.Location { })
+    if grammar == nil || occurred { return nil }
+    if len(grammar.Rules) == 0 || len(grammar.Tokens) == 0 {
+        Error(fmt.Sprintf("Grammar definition must contain at least one rule and token - %s", path))
+        return nil
+    }
+    l.removeDuplicates(grammar)
+    return grammar
+}
+
+func (l *GrammarLoader) load(path string, from string, location parser.Location) *GrammarNode {
+    key, err := filepath.Abs(path)
+    if err != nil { key = filepath.Clean(path) }
+    // Report import cycle if file is already being loaded
+    for i, file := range l.stack {
+        if file != key { continue }
+        cycle := make([]string, 0, len(l.stack) - i + 1)
+        for _, f := range l.stack[i:] { cycle = append(cycle, filepath.Base(f)) }
+        cycle = append(cycle, filepath.Base(key))
+        Error(fmt.Sprintf("Import cycle detected (%s) - %s:%d:%d", strings.Join(cycle, " -> "), from, location.Line, location.Col))
+        return nil
+    }
+    // Files imported multiple times are only included once
+    if _, ok := l.loaded[key]; ok { return nil }
+    l.loaded[key] = struct{}{}
+    f, err := os.Open(path)
+    if err != nil {
+        if from == "" {
+            Error(fmt.Sprintf("Cannot open grammar definition file \"%s\"", path))
+        } else {
+            Error(fmt.Sprintf("Cannot open imported file \"%s\" - %s:%d:%d", path, from, location.Line, location.Col))
+        }
+        return nil
+    }
+    defer f.Close()
+
+    // Parse file, syntax errors are prefixed with the file name
+    failed := false
+    lexer := parser.NewLexer(f, func (stream *parser.InputStream, char rune, location parser.Location) {
+        fmt.Fprintf(os.Stderr, "%s: ", path)
+        parser.DEFAULT_LEXER_HANDLER(stream, char, location)
+        failed = true
+    })
+    tree := parser.NewParser(lexer, func (token parser.Token, message string) {
+        fmt.Fprintf(os.Stderr, "%s: ", path)
+        parser.DEFAULT_PARSER_HANDLER(token, message)
+        failed = true
+    }).Parse()
+    if failed { occurred = true; return nil }
+    l.stack = append(l.stack, key)
+    grammar := NewParseTreeVisitor(l, path).VisitGrammar(parser.GrammarNode { ParseTreeNode: tree }).(*GrammarNode)
+    l.stack = l.stack[:len(l.stack) - 1]
+    return grammar
+}
+
+func (l *GrammarLoader) declare(id *IdentifierNode, path string) { l.files[id] = path }
+
+func (l *GrammarLoader) removeDuplicates(grammar *GrammarNode) {
+    // Fragments and tokens share the same namespace, while precedence levels and modes each have their own
+    identifiers := make(map[string]*IdentifierNode, len(grammar.Fragments) + len(grammar.Tokens))
+    grammar.Fragments = unique(l, grammar.Fragments, identifiers, "Fragment", func (n *FragmentNode) *IdentifierNode { return n.Identifier })
+    grammar.Tokens = unique(l, grammar.Tokens, identifiers, "Token", func (n *TokenNode) *IdentifierNode { return n.Identifier })
+    grammar.Precedence = unique(l, grammar.Precedence, make(map[string]*IdentifierNode), "Precedence",
+        func (n *PrecedenceNode) *IdentifierNode { return n.Identifier })
+    grammar.Modes = unique(l, grammar.Modes, make(map[string]*IdentifierNode), "Mode", func (n *ModeNode) *IdentifierNode { return n.Identifier })
+    // Rules with the same name are merged within a file, but cannot be defined by multiple files
+    rules, defined := make([]*RuleNode, 0, len(grammar.Rules)), make(map[string]*IdentifierNode, len(grammar.Rules))
+    for _, rule := range grammar.Rules {
+        id := rule.Identifier
+        previous, ok := defined[id.Name]
+        if ok && l.files[previous] != l.files[id] {
+            Error(fmt.Sprintf("Rule \"%s\" is already defined - %s (previously defined at %s)", id.Name, l.location(id), l.location(previous)))
+            continue
+        }
+        if !ok { defined[id.Name] = id }
+        rules = append(rules, rule)
+    }
+    grammar.Rules = rules
+}
+
+func unique[T any](l *GrammarLoader, nodes []T, identifiers map[string]*IdentifierNode, kind string, identifier func (T) *IdentifierNode) []T {
+    // Report and remove nodes with identifiers that are already defined
+    result := make([]T, 0, len(nodes))
+    for _, node := range nodes {
+        id := identifier(node)
+        if previous, ok := identifiers[id.Name]; ok {
+            if _, ok := l.files[previous]; ok {
+                Error(fmt.Sprintf("%s \"%s\" is already defined - %s (previously defined at %s)", kind, id.Name, l.location(id), l.location(previous)))
+            } else {
+                Error(fmt.Sprintf("%s \"%s\" is already defined - %s", kind, id.Name, l.location(id))) // Implicitly defined
+            }
+            continue
+        }
+        identifiers[id.Name] = id
+        result = append(result, node)
+    }
+    return result
+}
+
+func (l *GrammarLoader) location(id *IdentifierNode) string {
+    if path, ok := l.files[id]; ok { return fmt.Sprintf("%s:%d:%d", path, id.Start.Line, id.Start.Col) }
+    return fmt.Sprintf("%d:%d", id.Start.Line, id.Start.Col)
+}
@@ -13,103 +13,190 @@ type TokenType uint
 // Location struct. Holds line and column of token.
 type Location struct { Line, Col int }
 // Token struct. Holds type, value, and location of token.
+// Also holds the hidden tokens that occur between the previous token and this token.
 type Token struct {
     Type       TokenType
     Value      string
     Start, End Location
+    Trivia     []Token
 }
 
 // Represents a range between characters.
 type Range struct { Min, Max rune }
 
-const (WHITESPACE TokenType = iota; COMMENT; RULE; PRECEDENCE; TOKEN; FRAGMENT; LEFT; RIGHT; ERROR; SKIP; EQUAL; PLUS; STAR; QUESTION; DOT; BAR; HASH; PERCENT; SEMI; COLON; L_PAREN; R_PAREN; ARROW; IDENTIFIER; STRING; CLASS; EOF)
+const (WHITESPACE TokenType = iota; COMMENT; RULE; PRECEDENCE; TOKEN; FRAGMENT; LEFT; RIGHT; NONASSOC; ERROR; SKIP; MODE; PUSH_MODE; POP_MODE; NOCASE; IMPORT; CHANNEL; START; INLINE; OPTION; EQUAL; PLUS; MINUS; AND; BANG; CARET; STAR; QUESTION; DOT; BAR; HASH; PERCENT; PCT_PLUS; SEMI; COMMA; COLON; L_PAREN; R_PAREN; L_BRACE; R_BRACE; L_ANGLE; R_ANGLE; ARROW; IDENTIFIER; INTEGER; STRING; ISTRING; CLASS; EOF)
 func (t TokenType) String() string { return typeName[t] }
-var typeName = map[TokenType]string { 0: "WHITESPACE", 1: "COMMENT", 2: "RULE", 3: "PRECEDENCE", 4: "TOKEN", 5: "FRAGMENT", 6: "LEFT", 7: "RIGHT", 8: "ERROR", 9: "SKIP", 10: "EQUAL", 11: "PLUS", 12: "STAR", 13: "QUESTION", 14: "DOT", 15: "BAR", 16: "HASH", 17: "PERCENT", 18: "SEMI", 19: "COLON", 20: "L_PAREN", 21: "R_PAREN", 22: "ARROW", 23: "IDENTIFIER", 24: "STRING", 25: "CLASS", 26: "EOF" }
+var typeName = map[TokenType]string { 0: "WHITESPACE", 1: "COMMENT", 2: "RULE", 3: "PRECEDENCE", 4: "TOKEN", 5: "FRAGMENT", 6: "LEFT", 7: "RIGHT", 8: "NONASSOC", 9: "ERROR", 10: "SKIP", 11: "MODE", 12: "PUSH_MODE", 13: "POP_MODE", 14: "NOCASE", 15: "IMPORT", 16: "CHANNEL", 17: "START", 18: "INLINE", 19: "OPTION", 20: "EQUAL", 21: "PLUS", 22: "MINUS", 23: "AND", 24: "BANG", 25: "CARET", 26: "STAR", 27: "QUESTION", 28: "DOT", 29: "BAR", 30: "HASH", 31: "PERCENT", 32: "PCT_PLUS", 33: "SEMI", 34: "COMMA", 35: "COLON", 36: "L_PAREN", 37: "R_PAREN", 38: "L_BRACE", 39: "R_BRACE", 40: "L_ANGLE", 41: "R_ANGLE", 42: "ARROW", 43: "IDENTIFIER", 44: "INTEGER", 45: "STRING", 46: "ISTRING", 47: "CLASS", 48: "EOF" }
 var skip = map[TokenType]struct{} { 0: {}, 1: {} }
+var hidden = map[TokenType]struct{} {  }
 
-var ranges = []Range { { '\x00', '\x00' }, { '\x01', '\b' }, { '\t', '\t' }, { '\n', '\n' }, { '\v', '\f' }, { '\r', '\r' }, { '\x0e', '\x1f' }, { ' ', ' ' }, { '!', '!' }, { '"', '"' }, { '#', '#' }, { '$', '$' }, { '%', '%' }, { '&', '\'' }, { '(', '(' }, { ')', ')' }, { '*', '*' }, { '+', '+' }, { ',', ',' }, { '-', '-' }, { '.', '.' }, { '/', '/' }, { '0', '9' }, { ':', ':' }, { ';', ';' }, { '<', '<' }, { '=', '=' }, { '>', '>' }, { '?', '?' }, { '@', '@' }, { 'A', 'F' }, { 'G', 'T' }, { 'U', 'U' }, { 'V', 'Z' }, { '[', '[' }, { '\\', '\\' }, { ']', ']' }, { '^', '^' }, { '_', '_' }, { '`', '`' }, { 'a', 'a' }, { 'b', 'b' }, { 'c', 'c' }, { 'd', 'd' }, { 'e', 'e' }, { 'f', 'f' }, { 'g', 'g' }, { 'h', 'h' }, { 'i', 'i' }, { 'j', 'j' }, { 'k', 'k' }, { 'l', 'l' }, { 'm', 'm' }, { 'n', 'n' }, { 'o', 'o' }, { 'p', 'p' }, { 'q', 'q' }, { 'r', 'r' }, { 's', 's' }, { 't', 't' }, { 'u', 'u' }, { 'v', 'w' }, { 'x', 'x' }, { 'y', 'z' }, { '{', '{' }, { '|', '|' }, { '}', '\U0010ffff' } }
+var ranges = []Range { { '\x00', '\x00' }, { '\x01', '\b' }, { '\t', '\t' }, { '\n', '\n' }, { '\v', '\f' }, { '\r', '\r' }, { '\x0e', '\x1f' }, { ' ', ' ' }, { '!', '!' }, { '"', '"' }, { '#', '#' }, { '$', '$' }, { '%', '%' }, { '&', '&' }, { '\'', '\'' }, { '(', '(' }, { ')', ')' }, { '*', '*' }, { '+', '+' }, { ',', ',' }, { '-', '-' }, { '.', '.' }, { '/', '/' }, { '0', '9' }, { ':', ':' }, { ';', ';' }, { '<', '<' }, { '=', '=' }, { '>', '>' }, { '?', '?' }, { '@', '@' }, { 'A', 'F' }, { 'G', 'L' }, { 'M', 'M' }, { 'N', 'T' }, { 'U', 'U' }, { 'V', 'Z' }, { '[', '[' }, { '\\', '\\' }, { ']', ']' }, { '^', '^' }, { '_', '_' }, { '`', '`' }, { 'a', 'a' }, { 'b', 'b' }, { 'c', 'c' }, { 'd', 'd' }, { 'e', 'e' }, { 'f', 'f' }, { 'g', 'g' }, { 'h', 'h' }, { 'i', 'i' }, { 'j', 'j' }, { 'k', 'k' }, { 'l', 'l' }, { 'm', 'm' }, { 'n', 'n' }, { 'o', 'o' }, { 'p', 'p' }, { 'q', 'q' }, { 'r', 'r' }, { 's', 's' }, { 't', 't' }, { 'u', 'u' }, { 'v', 'w' }, { 'x', 'x' }, { 'y', 'z' }, { '{', '{' }, { '|', '|' }, { '}', '}' }, { '~', '\U0010ffff' } }
 var transitions = []map[int]int {
-    { 51: 37, 17: 29, 12: 44, 58: 17, 14: 14, 7: 12, 40: 74, 30: 74, 61: 74, 44: 69, 60: 74, 2: 12, 63: 74, 43: 74, 31: 74, 41: 74, 15: 10, 53: 74, 45: 52, 10: 32, 3: 12, 54: 74, 32: 74, 33: 74, 20: 38, 49: 74, 9: 45, 62: 74, 47: 74, 23: 47, 16: 25, 21: 54, 57: 21, 42: 74, 28: 66, 59: 48, 48: 74, 65: 6, 38: 74, 0: 63, 55: 11, 50: 74, 5: 12, 52: 74, 19: 49, 56: 74, 26: 16, 34: 77, 24: 9, 46: 74 },
-    { 45: 74, 38: 74, 50: 74, 46: 74, 61: 74, 33: 74, 58: 74, 56: 74, 62: 74, 57: 74, 41: 74, 52: 74, 32: 74, 43: 74, 51: 74, 60: 74, 47: 74, 59: 74, 40: 74, 48: 74, 54: 74, 31: 74, 53: 74, 44: 74, 49: 74, 22: 74, 55: 65, 30: 74, 42: 74, 63: 74 },
-    { 45: 74, 44: 74, 60: 74, 31: 74, 54: 74, 52: 74, 30: 74, 51: 74, 40: 74, 62: 74, 61: 74, 41: 74, 46: 74, 58: 74, 50: 74, 43: 74, 59: 74, 56: 74, 53: 74, 55: 74, 42: 74, 32: 74, 33: 74, 48: 74, 63: 74, 57: 74, 38: 74, 22: 74, 49: 74, 47: 74 },
+    { 0: 1, 2: 2, 3: 2, 5: 2, 7: 2, 8: 3, 9: 4, 10: 5, 12: 6, 13: 7, 15: 8, 16: 9, 17: 10, 18: 11, 19: 12, 20: 13, 21: 14, 22: 15, 23: 16, 24: 17, 25: 18, 26: 19, 27: 20, 28: 21, 29: 22, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 37: 24, 40: 25, 41: 23, 43: 23, 44: 23, 45: 26, 46: 23, 47: 27, 48: 28, 49: 23, 50: 23, 51: 29, 52: 23, 53: 23, 54: 30, 55: 31, 56: 32, 57: 33, 58: 34, 59: 23, 60: 35, 61: 36, 62: 37, 63: 23, 64: 23, 65: 23, 66: 23, 67: 38, 68: 39, 69: 40 },
     { },
-    { 50: 74, 31: 74, 32: 74, 40: 74, 58: 74, 52: 74, 33: 74, 30: 74, 38: 74, 22: 74, 42: 74, 59: 74, 53: 74, 48: 74, 45: 74, 62: 74, 57: 74, 44: 74, 49: 74, 47: 74, 51: 74, 63: 74, 54: 74, 60: 74, 55: 74, 61: 74, 56: 74, 46: 74, 41: 74, 43: 74 },
-    { 42: 72, 43: 72, 44: 72, 45: 72, 22: 72, 30: 72, 40: 72, 41: 72 },
+    { 2: 2, 3: 2, 5: 2, 7: 2 },
     { },
-    { 40: 39, 41: 39, 42: 39, 43: 39, 44: 39, 45: 39, 22: 39, 30: 39 },
-    { 43: 74, 58: 74, 44: 74, 22: 74, 55: 74, 48: 74, 61: 74, 49: 74, 31: 74, 47: 74, 50: 74, 51: 74, 33: 74, 32: 74, 56: 74, 52: 74, 42: 68, 46: 74, 41: 74, 45: 74, 53: 74, 38: 74, 62: 74, 60: 74, 59: 74, 30: 74, 57: 74, 40: 74, 63: 74, 54: 74 },
+    { 1: 4, 2: 4, 4: 4, 6: 4, 7: 4, 8: 4, 9: 41, 10: 4, 11: 4, 12: 4, 13: 4, 14: 4, 15: 4, 16: 4, 17: 4, 18: 4, 19: 4, 20: 4, 21: 4, 22: 4, 23: 4, 24: 4, 25: 4, 26: 4, 27: 4, 28: 4, 29: 4, 30: 4, 31: 4, 32: 4, 33: 4, 34: 4, 35: 4, 36: 4, 37: 4, 38: 42, 39: 4, 40: 4, 41: 4, 42: 4, 43: 4, 44: 4, 45: 4, 46: 4, 47: 4, 48: 4, 49: 4, 50: 4, 51: 4, 52: 4, 53: 4, 54: 4, 55: 4, 56: 4, 57: 4, 58: 4, 59: 4, 60: 4, 61: 4, 62: 4, 63: 4, 64: 4, 65: 4, 66: 4, 67: 4, 68: 4, 69: 4, 70: 4 },
     { },
+    { 18: 43 },
+    { 13: 44 },
     { },
-    { 47: 74, 43: 74, 54: 74, 32: 74, 31: 74, 62: 74, 50: 74, 58: 74, 56: 74, 49: 74, 60: 74, 22: 74, 52: 74, 33: 74, 42: 74, 44: 74, 40: 74, 53: 74, 48: 74, 46: 74, 59: 74, 51: 74, 61: 74, 38: 74, 55: 74, 63: 74, 57: 35, 41: 74, 45: 74, 30: 74 },
-    { 5: 12, 7: 12, 2: 12, 3: 12 },
-    { 32: 74, 44: 74, 50: 74, 22: 74, 56: 74, 45: 74, 57: 74, 60: 74, 63: 74, 61: 74, 43: 74, 38: 74, 58: 74, 46: 74, 55: 74, 51: 74, 48: 1, 53: 74, 30: 74, 33: 74, 52: 74, 49: 74, 31: 74, 62: 74, 42: 74, 59: 74, 40: 74, 41: 74, 54: 74, 47: 74 },
     { },
-    { 40: 46, 41: 46, 43: 46, 22: 46, 42: 46, 44: 46, 45: 46, 30: 46 },
     { },
-    { 49: 74, 52: 74, 45: 74, 32: 74, 57: 74, 40: 74, 31: 74, 50: 13, 54: 74, 47: 74, 63: 74, 44: 74, 61: 74, 56: 74, 33: 74, 62: 74, 46: 74, 48: 74, 42: 74, 30: 74, 60: 74, 51: 74, 59: 74, 53: 74, 41: 74, 38: 74, 43: 74, 55: 74, 22: 74, 58: 74 },
-    { 38: 74, 43: 74, 40: 41, 55: 74, 62: 74, 60: 74, 57: 74, 59: 74, 46: 74, 48: 74, 50: 74, 52: 74, 56: 74, 45: 74, 41: 74, 53: 74, 47: 74, 49: 74, 42: 74, 33: 74, 58: 74, 44: 74, 51: 74, 22: 74, 61: 74, 30: 74, 31: 74, 63: 74, 32: 74, 54: 74 },
-    { 1: 45, 50: 45, 12: 45, 17: 45, 63: 45, 38: 45, 56: 45, 10: 45, 65: 45, 40: 45, 29: 45, 47: 45, 23: 45, 24: 45, 21: 45, 58: 45, 4: 45, 13: 45, 34: 45, 36: 45, 15: 45, 52: 45, 7: 45, 55: 45, 11: 45, 22: 45, 14: 45, 39: 45, 66: 45, 53: 45, 27: 45, 46: 45, 35: 45, 42: 45, 62: 46, 54: 45, 9: 45, 8: 45, 59: 45, 43: 45, 28: 45, 64: 45, 19: 45, 18: 45, 57: 45, 25: 45, 33: 45, 41: 45, 60: 58, 2: 45, 61: 45, 45: 45, 26: 45, 32: 7, 37: 45, 51: 45, 30: 45, 31: 45, 48: 45, 44: 45, 49: 45, 16: 45, 20: 45, 6: 45 },
-    { 43: 58, 44: 58, 45: 58, 22: 58, 30: 58, 40: 58, 41: 58, 42: 58 },
-    { 38: 74, 49: 74, 31: 74, 42: 74, 45: 74, 47: 74, 57: 74, 50: 74, 46: 74, 33: 74, 40: 74, 63: 74, 53: 74, 58: 74, 51: 74, 41: 74, 54: 74, 61: 74, 62: 74, 48: 70, 59: 74, 44: 74, 22: 74, 60: 27, 52: 74, 55: 74, 32: 74, 30: 74, 43: 74, 56: 74 },
-    { 30: 31, 41: 31, 44: 31, 45: 31, 40: 31, 42: 31, 43: 31, 22: 31 },
-    { 33: 74, 45: 74, 50: 74, 38: 74, 48: 74, 56: 74, 46: 74, 59: 74, 42: 74, 54: 74, 49: 74, 22: 74, 61: 74, 62: 74, 51: 74, 31: 74, 60: 74, 40: 74, 57: 74, 41: 74, 63: 74, 47: 74, 32: 74, 53: 74, 43: 74, 44: 74, 30: 74, 55: 74, 52: 74, 58: 74 },
-    { 41: 74, 55: 74, 42: 74, 48: 74, 32: 74, 61: 74, 45: 74, 52: 74, 60: 74, 53: 74, 30: 74, 56: 74, 49: 74, 62: 74, 22: 74, 40: 74, 57: 74, 63: 74, 31: 74, 44: 74, 54: 73, 38: 74, 50: 74, 47: 74, 43: 74, 46: 74, 33: 74, 58: 74, 59: 74, 51: 74 },
     { },
-    { 19: 62, 51: 62, 21: 3, 36: 62, 43: 62, 47: 62, 34: 62, 23: 62, 25: 62, 5: 62, 33: 62, 54: 62, 3: 62, 24: 62, 10: 62, 28: 62, 49: 62, 64: 62, 45: 62, 13: 62, 65: 62, 32: 62, 26: 62, 20: 62, 9: 62, 39: 62, 7: 62, 62: 62, 66: 62, 30: 62, 44: 62, 60: 62, 6: 62, 59: 62, 29: 62, 55: 62, 53: 62, 15: 62, 52: 62, 16: 62, 58: 62, 61: 62, 8: 62, 41: 62, 22: 62, 35: 62, 37: 62, 38: 62, 46: 62, 31: 62, 50: 62, 11: 62, 1: 62, 18: 62, 63: 62, 14: 62, 40: 62, 48: 62, 4: 62, 42: 62, 57: 62, 17: 62, 2: 62, 56: 62, 27: 62, 12: 62 },
-    { 51: 28, 55: 74, 47: 74, 30: 74, 43: 74, 33: 74, 62: 74, 58: 74, 41: 74, 49: 74, 54: 74, 60: 74, 46: 74, 42: 74, 63: 74, 31: 74, 38: 74, 48: 74, 50: 74, 45: 74, 40: 74, 61: 74, 32: 74, 57: 74, 56: 74, 44: 74, 59: 74, 53: 74, 52: 74, 22: 74 },
-    { 55: 74, 63: 74, 52: 74, 56: 74, 60: 74, 45: 74, 40: 74, 48: 74, 41: 74, 57: 74, 43: 74, 42: 74, 46: 74, 31: 74, 47: 74, 58: 74, 54: 74, 62: 74, 30: 74, 33: 74, 44: 34, 59: 74, 49: 74, 38: 74, 22: 74, 53: 74, 51: 74, 61: 74, 32: 74, 50: 74 },
     { },
-    { 22: 64, 30: 64, 40: 64, 41: 64, 42: 64, 43: 64, 44: 64, 45: 64 },
-    { 43: 5, 22: 5, 30: 5, 40: 5, 42: 5, 44: 5, 45: 5, 41: 5 },
+    { 28: 45 },
     { },
-    { 59: 74, 49: 74, 55: 74, 51: 74, 48: 74, 57: 74, 45: 74, 44: 74, 53: 74, 46: 74, 63: 74, 32: 74, 41: 74, 22: 74, 52: 74, 42: 74, 61: 74, 38: 74, 56: 74, 33: 74, 62: 74, 50: 74, 54: 74, 31: 74, 47: 42, 43: 74, 40: 74, 58: 74, 30: 74, 60: 74 },
-    { 30: 74, 63: 74, 59: 74, 49: 74, 55: 74, 22: 74, 61: 74, 62: 74, 46: 74, 51: 74, 60: 74, 40: 74, 48: 74, 42: 74, 50: 74, 32: 74, 38: 74, 57: 74, 52: 74, 56: 74, 43: 74, 47: 74, 41: 74, 58: 74, 44: 74, 31: 74, 33: 74, 54: 74, 53: 74, 45: 74 },
-    { 54: 74, 40: 74, 38: 74, 47: 74, 51: 74, 60: 74, 55: 74, 33: 74, 56: 74, 30: 74, 58: 74, 31: 74, 62: 74, 32: 74, 41: 74, 59: 74, 52: 74, 63: 74, 61: 74, 53: 74, 44: 8, 48: 74, 22: 74, 49: 74, 45: 74, 46: 74, 57: 74, 42: 74, 43: 74, 50: 74 },
+    { 17: 46, 22: 47 },
+    { 23: 16 },
     { },
-    { 44: 71, 41: 74, 62: 74, 63: 74, 32: 74, 48: 74, 31: 74, 40: 74, 30: 74, 49: 74, 61: 74, 22: 74, 56: 74, 59: 74, 43: 74, 60: 74, 50: 74, 33: 74, 57: 74, 51: 74, 42: 74, 53: 74, 52: 74, 38: 74, 55: 74, 54: 74, 47: 74, 45: 74, 46: 74, 58: 74 },
     { },
-    { 44: 59, 45: 59, 22: 59, 30: 59, 40: 59, 41: 59, 42: 59, 43: 59 },
-    { 49: 74, 33: 74, 30: 74, 62: 74, 50: 43, 40: 74, 44: 74, 46: 74, 38: 74, 45: 74, 60: 74, 51: 74, 61: 74, 58: 74, 32: 74, 59: 74, 41: 74, 57: 74, 22: 74, 48: 74, 54: 74, 42: 74, 52: 74, 47: 74, 31: 74, 55: 74, 56: 74, 43: 74, 53: 74, 63: 74 },
-    { 32: 74, 42: 74, 55: 74, 50: 74, 51: 74, 58: 74, 30: 74, 40: 74, 44: 74, 62: 74, 38: 74, 22: 74, 59: 74, 57: 74, 60: 74, 49: 74, 48: 74, 63: 74, 53: 74, 54: 74, 31: 74, 52: 74, 47: 74, 56: 74, 33: 74, 61: 74, 45: 74, 46: 75, 43: 74, 41: 74 },
-    { 40: 74, 58: 74, 38: 74, 61: 74, 56: 74, 44: 74, 43: 74, 31: 74, 47: 74, 30: 74, 49: 74, 50: 74, 62: 74, 54: 74, 42: 74, 55: 74, 57: 74, 48: 74, 46: 74, 33: 74, 41: 74, 53: 74, 51: 74, 59: 76, 22: 74, 45: 74, 32: 74, 52: 74, 63: 74, 60: 74 },
-    { 57: 74, 51: 74, 31: 74, 60: 74, 63: 74, 33: 74, 62: 74, 22: 74, 52: 74, 40: 74, 54: 74, 58: 74, 50: 74, 49: 74, 53: 74, 42: 74, 41: 74, 48: 74, 44: 67, 47: 74, 55: 74, 45: 74, 38: 74, 30: 74, 46: 74, 59: 74, 56: 74, 32: 74, 61: 74, 43: 74 },
     { },
-    { 45: 45, 53: 45, 33: 45, 27: 45, 24: 45, 46: 45, 13: 45, 60: 45, 50: 45, 10: 45, 6: 45, 38: 45, 64: 45, 16: 45, 12: 45, 17: 45, 7: 45, 28: 45, 1: 45, 8: 45, 22: 45, 18: 45, 23: 45, 9: 60, 63: 45, 29: 45, 41: 45, 19: 45, 20: 45, 21: 45, 43: 45, 35: 19, 49: 45, 56: 45, 44: 45, 54: 45, 65: 45, 48: 45, 58: 45, 62: 45, 47: 45, 32: 45, 4: 45, 11: 45, 66: 45, 55: 45, 52: 45, 51: 45, 42: 45, 59: 45, 2: 45, 57: 45, 26: 45, 34: 45, 40: 45, 31: 45, 39: 45, 30: 45, 15: 45, 25: 45, 61: 45, 36: 45, 14: 45, 37: 45 },
-    { 43: 53, 44: 53, 30: 53, 40: 53, 41: 53, 45: 53, 22: 53, 42: 53 },
     { },
-    { 38: 74, 49: 74, 54: 40, 40: 74, 45: 74, 47: 74, 48: 74, 44: 74, 62: 74, 31: 74, 57: 74, 63: 74, 50: 74, 32: 74, 46: 74, 61: 74, 51: 74, 55: 74, 41: 74, 43: 74, 60: 74, 42: 74, 59: 74, 22: 74, 58: 74, 53: 74, 56: 74, 30: 74, 52: 74, 33: 74 },
-    { 27: 50 },
     { },
-    { 45: 74, 33: 74, 61: 74, 57: 24, 31: 74, 43: 74, 58: 74, 46: 74, 41: 74, 53: 74, 50: 74, 60: 74, 63: 74, 56: 74, 40: 74, 22: 74, 30: 74, 49: 74, 51: 74, 59: 74, 55: 74, 52: 74, 38: 74, 47: 74, 44: 74, 54: 74, 62: 74, 48: 74, 42: 74, 32: 74 },
-    { 46: 74, 43: 74, 31: 74, 47: 74, 54: 74, 56: 74, 40: 74, 63: 74, 32: 74, 55: 74, 41: 74, 44: 74, 38: 74, 33: 74, 52: 74, 53: 74, 62: 74, 51: 74, 59: 74, 45: 74, 60: 74, 61: 74, 48: 74, 42: 74, 22: 74, 30: 74, 58: 74, 57: 18, 50: 74, 49: 74 },
-    { 45: 45, 30: 45, 41: 45, 42: 45, 43: 45, 44: 45, 22: 45, 40: 45 },
-    { 21: 61, 16: 62 },
-    { 30: 30, 40: 30, 41: 30, 42: 30, 43: 30, 44: 30, 45: 30, 22: 30 },
-    { 30: 77, 42: 77, 45: 77, 40: 77, 41: 77, 43: 77, 44: 77, 22: 77 },
-    { 53: 74, 55: 74, 59: 23, 32: 74, 48: 74, 54: 74, 58: 74, 46: 74, 50: 74, 43: 74, 60: 74, 22: 74, 33: 74, 40: 74, 57: 74, 42: 74, 61: 74, 38: 74, 63: 74, 49: 74, 56: 74, 51: 74, 41: 74, 52: 74, 44: 74, 47: 74, 31: 74, 30: 74, 62: 74, 45: 74 },
-    { 30: 15, 40: 15, 41: 15, 42: 15, 43: 15, 44: 15, 45: 15, 22: 15 },
-    { 22: 20, 30: 20, 40: 20, 41: 20, 42: 20, 43: 20, 44: 20, 45: 20 },
     { },
-    { 56: 61, 64: 61, 62: 61, 5: 3, 18: 61, 34: 61, 16: 61, 36: 61, 41: 61, 8: 61, 57: 61, 40: 61, 24: 61, 2: 61, 26: 61, 20: 61, 10: 61, 65: 61, 43: 61, 37: 61, 17: 61, 12: 61, 4: 61, 7: 61, 54: 61, 49: 61, 47: 61, 3: 3, 58: 61, 19: 61, 55: 61, 28: 61, 1: 61, 31: 61, 33: 61, 48: 61, 52: 61, 50: 61, 46: 61, 66: 61, 13: 61, 35: 61, 53: 61, 23: 61, 0: 3, 61: 61, 45: 61, 59: 61, 42: 61, 25: 61, 32: 61, 30: 61, 63: 61, 11: 61, 6: 61, 29: 61, 9: 61, 22: 61, 14: 61, 15: 61, 51: 61, 38: 61, 27: 61, 39: 61, 21: 61, 60: 61, 44: 61 },
-    { 25: 62, 6: 62, 42: 62, 23: 62, 64: 62, 36: 62, 61: 62, 24: 62, 26: 62, 15: 62, 40: 62, 55: 62, 9: 62, 52: 62, 38: 62, 58: 62, 8: 62, 30: 62, 20: 62, 39: 62, 50: 62, 54: 62, 11: 62, 10: 62, 59: 62, 1: 62, 65: 62, 7: 62, 44: 62, 35: 62, 2: 62, 4: 62, 43: 62, 56: 62, 21: 62, 37: 62, 22: 62, 3: 62, 49: 62, 31: 62, 60: 62, 14: 62, 63: 62, 57: 62, 62: 62, 16: 26, 34: 62, 12: 62, 18: 62, 32: 62, 13: 62, 17: 62, 41: 62, 47: 62, 19: 62, 46: 62, 45: 62, 5: 62, 51: 62, 33: 62, 66: 62, 48: 62, 53: 62, 28: 62, 27: 62, 29: 62 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 1: 24, 2: 24, 4: 24, 6: 24, 7: 24, 8: 24, 9: 24, 10: 24, 11: 24, 12: 24, 13: 24, 14: 24, 15: 24, 16: 24, 17: 24, 18: 24, 19: 24, 20: 24, 21: 24, 22: 24, 23: 24, 24: 24, 25: 24, 26: 24, 27: 24, 28: 24, 29: 24, 30: 24, 31: 24, 32: 24, 33: 24, 34: 24, 35: 24, 36: 24, 37: 24, 38: 48, 39: 49, 40: 24, 41: 24, 42: 24, 43: 24, 44: 24, 45: 24, 46: 24, 47: 24, 48: 24, 49: 24, 50: 24, 51: 24, 52: 24, 53: 24, 54: 24, 55: 24, 56: 24, 57: 24, 58: 24, 59: 24, 60: 24, 61: 24, 62: 24, 63: 24, 64: 24, 65: 24, 66: 24, 67: 24, 68: 24, 69: 24, 70: 24 },
     { },
-    { 43: 22, 44: 22, 45: 22, 22: 22, 30: 22, 40: 22, 41: 22, 42: 22 },
-    { 54: 74, 33: 74, 43: 74, 46: 74, 50: 74, 51: 74, 47: 74, 44: 74, 60: 74, 42: 74, 56: 74, 62: 74, 59: 74, 40: 74, 48: 74, 55: 74, 52: 74, 58: 74, 53: 74, 22: 74, 38: 74, 32: 74, 45: 74, 30: 74, 63: 74, 31: 74, 57: 74, 49: 74, 61: 74, 41: 74 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 50, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 51, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 52, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 9: 53, 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 54, 56: 55, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 56, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 57, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 58, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 59, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 60, 58: 23, 59: 23, 60: 61, 61: 23, 62: 23, 63: 62, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 63, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 64, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 65, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 66, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 67, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
     { },
-    { 49: 74, 54: 74, 59: 74, 32: 74, 62: 74, 53: 4, 41: 74, 51: 74, 30: 74, 43: 74, 45: 74, 40: 74, 60: 74, 38: 74, 50: 74, 63: 74, 55: 74, 61: 74, 56: 74, 47: 74, 48: 74, 58: 74, 31: 74, 52: 74, 57: 74, 44: 74, 46: 74, 33: 74, 42: 74, 22: 74 },
-    { 60: 74, 61: 74, 47: 74, 56: 74, 42: 74, 33: 74, 43: 74, 48: 74, 57: 74, 38: 74, 58: 74, 32: 74, 30: 74, 55: 74, 45: 74, 53: 74, 49: 74, 59: 74, 63: 74, 51: 74, 52: 74, 40: 74, 31: 74, 44: 74, 22: 74, 54: 74, 62: 74, 50: 74, 46: 74, 41: 74 },
-    { 22: 74, 49: 74, 45: 74, 51: 74, 47: 74, 44: 74, 62: 74, 54: 74, 32: 74, 50: 74, 53: 74, 52: 74, 42: 74, 63: 74, 56: 74, 57: 51, 40: 74, 33: 74, 48: 74, 31: 74, 38: 74, 55: 74, 43: 74, 59: 74, 60: 74, 46: 74, 41: 74, 61: 74, 58: 74, 30: 74 },
-    { 32: 74, 62: 74, 50: 74, 59: 74, 47: 74, 40: 74, 31: 74, 48: 74, 52: 74, 58: 74, 60: 74, 46: 33, 56: 74, 57: 74, 22: 74, 30: 74, 42: 74, 41: 74, 43: 74, 53: 74, 51: 74, 61: 74, 44: 74, 54: 74, 55: 74, 38: 74, 49: 74, 33: 74, 45: 74, 63: 74 },
-    { 40: 74, 51: 74, 54: 74, 32: 74, 38: 74, 53: 74, 57: 74, 46: 74, 63: 74, 59: 74, 47: 74, 50: 74, 48: 74, 31: 74, 58: 74, 45: 57, 30: 74, 41: 74, 56: 74, 33: 74, 43: 74, 49: 74, 60: 74, 55: 74, 22: 74, 42: 74, 44: 74, 52: 74, 62: 74, 61: 74 },
-    { 40: 56, 45: 56, 41: 56, 42: 56, 43: 56, 44: 56, 22: 56, 30: 56 },
-    { 42: 74, 38: 74, 57: 2, 33: 74, 59: 74, 56: 74, 45: 74, 58: 74, 55: 74, 51: 74, 63: 74, 40: 74, 54: 74, 32: 74, 48: 74, 62: 74, 52: 74, 43: 74, 22: 74, 30: 74, 49: 74, 50: 74, 46: 74, 61: 74, 31: 74, 53: 74, 47: 74, 44: 74, 41: 74, 60: 74 },
-    { 52: 74, 47: 74, 41: 74, 31: 74, 33: 74, 22: 74, 53: 74, 63: 74, 45: 74, 50: 74, 49: 74, 56: 74, 62: 74, 42: 74, 51: 74, 59: 74, 57: 74, 58: 74, 46: 74, 40: 74, 30: 74, 32: 74, 44: 74, 48: 74, 60: 74, 38: 74, 61: 74, 54: 74, 43: 74, 55: 74 },
-    { 49: 74, 31: 74, 50: 74, 33: 74, 45: 74, 56: 74, 57: 74, 52: 74, 42: 74, 43: 74, 30: 74, 44: 74, 38: 74, 53: 74, 48: 74, 46: 74, 61: 74, 32: 74, 40: 74, 41: 74, 55: 74, 51: 74, 63: 74, 59: 74, 54: 74, 62: 74, 22: 74, 58: 74, 47: 74, 60: 74 },
-    { 60: 74, 55: 74, 52: 74, 61: 74, 45: 74, 50: 74, 22: 74, 38: 74, 47: 74, 59: 74, 33: 74, 51: 74, 42: 74, 57: 74, 56: 74, 46: 74, 63: 74, 62: 74, 32: 74, 54: 74, 58: 74, 49: 74, 48: 74, 43: 74, 44: 74, 41: 74, 30: 74, 53: 74, 40: 74, 31: 74 },
-    { 22: 77, 21: 77, 47: 77, 53: 77, 48: 77, 46: 77, 19: 77, 15: 77, 40: 77, 33: 77, 30: 77, 58: 77, 44: 77, 10: 77, 38: 77, 29: 77, 8: 77, 57: 77, 28: 77, 9: 77, 42: 77, 41: 77, 65: 77, 23: 77, 24: 77, 25: 77, 7: 77, 39: 77, 49: 77, 37: 77, 55: 77, 51: 77, 27: 77, 17: 77, 11: 77, 59: 77, 56: 77, 13: 77, 26: 77, 45: 77, 43: 77, 50: 77, 14: 77, 12: 77, 20: 77, 31: 77, 66: 77, 36: 36, 62: 77, 61: 77, 64: 77, 52: 77, 4: 77, 60: 77, 18: 77, 63: 77, 34: 77, 35: 78, 16: 77, 54: 77, 32: 77, 2: 77, 6: 77, 1: 77 },
-    { 16: 77, 22: 77, 21: 77, 55: 77, 23: 77, 41: 77, 65: 77, 4: 77, 45: 77, 39: 77, 31: 77, 60: 31, 24: 77, 29: 77, 9: 77, 62: 72, 34: 77, 61: 77, 46: 77, 42: 77, 14: 77, 35: 77, 53: 77, 50: 77, 57: 77, 52: 77, 37: 77, 66: 77, 49: 77, 11: 77, 19: 77, 51: 77, 7: 77, 20: 77, 15: 77, 40: 77, 26: 77, 2: 77, 6: 77, 18: 77, 59: 77, 54: 77, 32: 55, 58: 77, 63: 77, 47: 77, 10: 77, 56: 77, 44: 77, 38: 77, 25: 77, 64: 77, 28: 77, 27: 77, 8: 77, 36: 77, 12: 77, 30: 77, 43: 77, 17: 77, 48: 77, 33: 77, 1: 77, 13: 77 },
+    { },
+    { },
+    { },
+    { 1: 4, 2: 4, 4: 4, 6: 4, 7: 4, 8: 4, 9: 4, 10: 4, 11: 4, 12: 4, 13: 4, 14: 4, 15: 4, 16: 4, 17: 4, 18: 4, 19: 4, 20: 4, 21: 4, 22: 4, 23: 4, 24: 4, 25: 4, 26: 4, 27: 4, 28: 4, 29: 4, 30: 4, 31: 4, 32: 4, 33: 4, 34: 4, 35: 68, 36: 4, 37: 4, 38: 4, 39: 4, 40: 4, 41: 4, 42: 4, 43: 4, 44: 4, 45: 4, 46: 4, 47: 4, 48: 4, 49: 4, 50: 4, 51: 4, 52: 4, 53: 4, 54: 4, 55: 4, 56: 4, 57: 4, 58: 4, 59: 4, 60: 4, 61: 4, 62: 4, 63: 69, 64: 4, 65: 70, 66: 4, 67: 4, 68: 4, 69: 4, 70: 4 },
+    { },
+    { },
+    { },
+    { 1: 46, 2: 46, 3: 46, 4: 46, 5: 46, 6: 46, 7: 46, 8: 46, 9: 46, 10: 46, 11: 46, 12: 46, 13: 46, 14: 46, 15: 46, 16: 46, 17: 71, 18: 46, 19: 46, 20: 46, 21: 46, 22: 46, 23: 46, 24: 46, 25: 46, 26: 46, 27: 46, 28: 46, 29: 46, 30: 46, 31: 46, 32: 46, 33: 46, 34: 46, 35: 46, 36: 46, 37: 46, 38: 46, 39: 46, 40: 46, 41: 46, 42: 46, 43: 46, 44: 46, 45: 46, 46: 46, 47: 46, 48: 46, 49: 46, 50: 46, 51: 46, 52: 46, 53: 46, 54: 46, 55: 46, 56: 46, 57: 46, 58: 46, 59: 46, 60: 46, 61: 46, 62: 46, 63: 46, 64: 46, 65: 46, 66: 46, 67: 46, 68: 46, 69: 46, 70: 46 },
+    { 0: 72, 1: 47, 2: 47, 3: 72, 4: 47, 5: 72, 6: 47, 7: 47, 8: 47, 9: 47, 10: 47, 11: 47, 12: 47, 13: 47, 14: 47, 15: 47, 16: 47, 17: 47, 18: 47, 19: 47, 20: 47, 21: 47, 22: 47, 23: 47, 24: 47, 25: 47, 26: 47, 27: 47, 28: 47, 29: 47, 30: 47, 31: 47, 32: 47, 33: 47, 34: 47, 35: 47, 36: 47, 37: 47, 38: 47, 39: 47, 40: 47, 41: 47, 42: 47, 43: 47, 44: 47, 45: 47, 46: 47, 47: 47, 48: 47, 49: 47, 50: 47, 51: 47, 52: 47, 53: 47, 54: 47, 55: 47, 56: 47, 57: 47, 58: 47, 59: 47, 60: 47, 61: 47, 62: 47, 63: 47, 64: 47, 65: 47, 66: 47, 67: 47, 68: 47, 69: 47, 70: 47 },
+    { 1: 24, 2: 24, 4: 24, 6: 24, 7: 24, 8: 24, 9: 24, 10: 24, 11: 24, 12: 24, 13: 24, 14: 24, 15: 24, 16: 24, 17: 24, 18: 24, 19: 24, 20: 24, 21: 24, 22: 24, 23: 24, 24: 24, 25: 24, 26: 24, 27: 24, 28: 24, 29: 24, 30: 24, 31: 24, 32: 24, 33: 24, 34: 24, 35: 73, 36: 24, 37: 24, 38: 24, 39: 24, 40: 24, 41: 24, 42: 24, 43: 24, 44: 24, 45: 24, 46: 24, 47: 24, 48: 24, 49: 24, 50: 24, 51: 24, 52: 24, 53: 24, 54: 24, 55: 24, 56: 24, 57: 24, 58: 24, 59: 24, 60: 24, 61: 24, 62: 24, 63: 74, 64: 24, 65: 75, 66: 24, 67: 24, 68: 24, 69: 24, 70: 24 },
+    { },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 76, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 77, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 78, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 1: 53, 2: 53, 4: 53, 6: 53, 7: 53, 8: 53, 9: 79, 10: 53, 11: 53, 12: 53, 13: 53, 14: 53, 15: 53, 16: 53, 17: 53, 18: 53, 19: 53, 20: 53, 21: 53, 22: 53, 23: 53, 24: 53, 25: 53, 26: 53, 27: 53, 28: 53, 29: 53, 30: 53, 31: 53, 32: 53, 33: 53, 34: 53, 35: 53, 36: 53, 37: 53, 38: 80, 39: 53, 40: 53, 41: 53, 42: 53, 43: 53, 44: 53, 45: 53, 46: 53, 47: 53, 48: 53, 49: 53, 50: 53, 51: 53, 52: 53, 53: 53, 54: 53, 55: 53, 56: 53, 57: 53, 58: 53, 59: 53, 60: 53, 61: 53, 62: 53, 63: 53, 64: 53, 65: 53, 66: 53, 67: 53, 68: 53, 69: 53, 70: 53 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 81, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 82, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 83, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 84, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 85, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 86, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 87, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 88, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 89, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 90, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 91, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 92, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 93, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 94, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 95, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 96, 31: 96, 43: 96, 44: 96, 45: 96, 46: 96, 47: 96, 48: 96 },
+    { 23: 97, 31: 97, 43: 97, 44: 97, 45: 97, 46: 97, 47: 97, 48: 97 },
+    { 23: 98, 31: 98, 43: 98, 44: 98, 45: 98, 46: 98, 47: 98, 48: 98 },
+    { 1: 46, 2: 46, 3: 46, 4: 46, 5: 46, 6: 46, 7: 46, 8: 46, 9: 46, 10: 46, 11: 46, 12: 46, 13: 46, 14: 46, 15: 46, 16: 46, 17: 46, 18: 46, 19: 46, 20: 46, 21: 46, 22: 72, 23: 46, 24: 46, 25: 46, 26: 46, 27: 46, 28: 46, 29: 46, 30: 46, 31: 46, 32: 46, 33: 46, 34: 46, 35: 46, 36: 46, 37: 46, 38: 46, 39: 46, 40: 46, 41: 46, 42: 46, 43: 46, 44: 46, 45: 46, 46: 46, 47: 46, 48: 46, 49: 46, 50: 46, 51: 46, 52: 46, 53: 46, 54: 46, 55: 46, 56: 46, 57: 46, 58: 46, 59: 46, 60: 46, 61: 46, 62: 46, 63: 46, 64: 46, 65: 46, 66: 46, 67: 46, 68: 46, 69: 46, 70: 46 },
+    { },
+    { 23: 99, 31: 99, 43: 99, 44: 99, 45: 99, 46: 99, 47: 99, 48: 99 },
+    { 23: 100, 31: 100, 43: 100, 44: 100, 45: 100, 46: 100, 47: 100, 48: 100 },
+    { 23: 101, 31: 101, 43: 101, 44: 101, 45: 101, 46: 101, 47: 101, 48: 101 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 102, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 103, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 104, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { },
+    { 1: 53, 2: 53, 4: 53, 6: 53, 7: 53, 8: 53, 9: 53, 10: 53, 11: 53, 12: 53, 13: 53, 14: 53, 15: 53, 16: 53, 17: 53, 18: 53, 19: 53, 20: 53, 21: 53, 22: 53, 23: 53, 24: 53, 25: 53, 26: 53, 27: 53, 28: 53, 29: 53, 30: 53, 31: 53, 32: 53, 33: 53, 34: 53, 35: 105, 36: 53, 37: 53, 38: 53, 39: 53, 40: 53, 41: 53, 42: 53, 43: 53, 44: 53, 45: 53, 46: 53, 47: 53, 48: 53, 49: 53, 50: 53, 51: 53, 52: 53, 53: 53, 54: 53, 55: 53, 56: 53, 57: 53, 58: 53, 59: 53, 60: 53, 61: 53, 62: 53, 63: 106, 64: 53, 65: 107, 66: 53, 67: 53, 68: 53, 69: 53, 70: 53 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 108, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 109, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 110, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 111, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 112, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 113, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 114, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 115, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 116, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 117, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 118, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 119, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 120, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 121, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 122, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 123, 31: 123, 43: 123, 44: 123, 45: 123, 46: 123, 47: 123, 48: 123 },
+    { 23: 70, 31: 70, 43: 70, 44: 70, 45: 70, 46: 70, 47: 70, 48: 70 },
+    { 23: 4, 31: 4, 43: 4, 44: 4, 45: 4, 46: 4, 47: 4, 48: 4 },
+    { 23: 124, 31: 124, 43: 124, 44: 124, 45: 124, 46: 124, 47: 124, 48: 124 },
+    { 23: 75, 31: 75, 43: 75, 44: 75, 45: 75, 46: 75, 47: 75, 48: 75 },
+    { 23: 24, 31: 24, 43: 24, 44: 24, 45: 24, 46: 24, 47: 24, 48: 24 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 125, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 126, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 127, 31: 127, 43: 127, 44: 127, 45: 127, 46: 127, 47: 127, 48: 127 },
+    { 23: 128, 31: 128, 43: 128, 44: 128, 45: 128, 46: 128, 47: 128, 48: 128 },
+    { 23: 129, 31: 129, 43: 129, 44: 129, 45: 129, 46: 129, 47: 129, 48: 129 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 130, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 131, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 132, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 133, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 134, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 135, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 136, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 137, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 138, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 139, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 140, 31: 140, 43: 140, 44: 140, 45: 140, 46: 140, 47: 140, 48: 140 },
+    { 23: 141, 31: 141, 43: 141, 44: 141, 45: 141, 46: 141, 47: 141, 48: 141 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 142, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 143, 31: 143, 43: 143, 44: 143, 45: 143, 46: 143, 47: 143, 48: 143 },
+    { 23: 107, 31: 107, 43: 107, 44: 107, 45: 107, 46: 107, 47: 107, 48: 107 },
+    { 23: 53, 31: 53, 43: 53, 44: 53, 45: 53, 46: 53, 47: 53, 48: 53 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 144, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 145, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 146, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 147, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 148, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 149, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 150, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 69, 31: 69, 43: 69, 44: 69, 45: 69, 46: 69, 47: 69, 48: 69 },
+    { 23: 74, 31: 74, 43: 74, 44: 74, 45: 74, 46: 74, 47: 74, 48: 74 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 151, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 152, 31: 152, 43: 152, 44: 152, 45: 152, 46: 152, 47: 152, 48: 152 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 153, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 154, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 155, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 106, 31: 106, 43: 106, 44: 106, 45: 106, 46: 106, 47: 106, 48: 106 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 156, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 157, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
+    { 23: 23, 31: 23, 32: 23, 33: 23, 34: 23, 35: 23, 36: 23, 41: 23, 43: 23, 44: 23, 45: 23, 46: 23, 47: 23, 48: 23, 49: 23, 50: 23, 51: 23, 52: 23, 53: 23, 54: 23, 55: 23, 56: 23, 57: 23, 58: 23, 59: 23, 60: 23, 61: 23, 62: 23, 63: 23, 64: 23, 65: 23, 66: 23 },
 }
-var accept = map[int]TokenType { 75: 5, 37: 23, 1: 23, 2: 8, 3: 1, 9: 18, 35: 23, 42: 23, 67: 23, 4: 4, 12: 0, 17: 23, 18: 23, 21: 23, 25: 12, 27: 23, 28: 23, 6: 15, 8: 23, 24: 23, 36: 25, 33: 23, 41: 23, 44: 17, 51: 23, 13: 23, 43: 23, 47: 19, 50: 22, 68: 3, 74: 23, 76: 7, 14: 20, 48: 23, 66: 13, 71: 23, 10: 21, 11: 23, 23: 6, 34: 2, 57: 23, 16: 10, 32: 16, 38: 14, 40: 23, 52: 23, 60: 24, 63: 26, 70: 23, 29: 11, 65: 9, 69: 23, 73: 23 }
+var accept = map[int]TokenType { 1: 48, 2: 0, 3: 24, 5: 30, 6: 31, 8: 36, 9: 37, 10: 26, 11: 21, 12: 34, 13: 22, 14: 28, 16: 44, 17: 35, 18: 33, 19: 40, 20: 20, 21: 41, 22: 27, 23: 43, 25: 25, 26: 43, 27: 43, 28: 43, 29: 43, 30: 43, 31: 43, 32: 43, 33: 43, 34: 43, 35: 43, 36: 43, 37: 43, 38: 38, 39: 29, 40: 39, 41: 45, 43: 32, 44: 23, 45: 42, 49: 47, 50: 43, 51: 43, 52: 43, 54: 43, 55: 43, 56: 43, 57: 43, 58: 43, 59: 43, 60: 43, 61: 43, 62: 43, 63: 43, 64: 43, 65: 43, 66: 43, 67: 43, 72: 1, 76: 43, 77: 43, 78: 43, 79: 46, 81: 43, 82: 43, 83: 43, 84: 43, 85: 43, 86: 43, 87: 43, 88: 43, 89: 43, 90: 43, 91: 43, 92: 43, 93: 43, 94: 43, 95: 43, 102: 43, 103: 43, 104: 5, 108: 43, 109: 43, 110: 6, 111: 11, 112: 43, 113: 43, 114: 43, 115: 43, 116: 3, 117: 43, 118: 43, 119: 2, 120: 10, 121: 43, 122: 43, 125: 43, 126: 9, 130: 43, 131: 43, 132: 43, 133: 43, 134: 43, 135: 43, 136: 43, 137: 7, 138: 17, 139: 4, 142: 43, 144: 15, 145: 18, 146: 14, 147: 43, 148: 19, 149: 43, 150: 43, 151: 16, 153: 43, 154: 13, 155: 43, 156: 8, 157: 12 }
+var starts = []int { 0 }
+var modeActions = map[TokenType]modeAction {  }
+
+// Mode action struct. Holds action type and the mode to transition to.
+type modeAction struct { actionType, mode int }
 
 // Base lexer interface.
 type BaseLexer interface { Next() Token }
@@ -117,6 +204,8 @@ type BaseLexer interface { Next() Token }
 type Lexer struct {
     stream  *InputStream
     handler LexerErrorHandler
+    modes   []int
+    trivia  []Token
 }
 
 // Input stream struct. Produces character stream.
@@ -151,7 +240,7 @@ var DEFAULT_LEXER_HANDLER = func (stream *InputStream, char rune, location Locat
 // Returns new lexer struct. Initializes lexer with initial token.
 func NewLexer(reader io.Reader, handler LexerErrorHandler) *Lexer {
     stream := &InputStream { bufio.NewReader(reader), Location { 1, 1 }, make([]streamData, 0), make([]streamData, 0) }
-    lexer := &Lexer { stream, handler }
+    lexer := &Lexer { stream, handler, []int { 0 }, nil }
     return lexer
 }
 
@@ -159,7 +248,8 @@ func NewLexer(reader io.Reader, handler LexerErrorHandler) *Lexer {
 func (l *Lexer) Next() Token {
     start := l.stream.location
     input, stack := make([]rune, 0), make([]int, 0)
-    i, state := 0, 0
+    // Start from the initial state of the current mode
+    i, state := 0, starts[l.modes[len(l.modes) - 1]]
     var char rune
     for {
         // Read current character in stream and add to input
@@ -192,9 +282,27 @@ func (l *Lexer) Next() Token {
     }
     end := l.stream.stack[len(l.stream.stack) - 1].location
     l.stream.reset()
+    if action, ok := modeActions[token]; ok { l.apply(action) }
     if _, ok := skip[token]; ok { return l.Next() } // Skip token
+    if _, ok := hidden[token]; ok {
+        // Store hidden token to be attached to the next token
+        l.trivia = append(l.trivia, Token { token, string(input[:i]), start, end, nil })
+        return l.Next()
+    }
     // Create token struct
-    return Token { token, string(input[:i]), start, end }
+    trivia := l.trivia; l.trivia = nil
+    return Token { token, string(input[:i]), start, end, trivia }
+}
+
+// Modifies the mode stack based on the mode action associated with a token.
+func (l *Lexer) apply(action modeAction) {
+    // Mode action type enum
+    const (PUSH_MODE int = iota; POP_MODE; SET_MODE)
+    switch action.actionType {
+    case PUSH_MODE: l.modes = append(l.modes, action.mode)
+    case POP_MODE:  if len(l.modes) > 1 { l.modes = l.modes[:len(l.modes) - 1] } // Initial mode is never popped
+    case SET_MODE:  l.modes[len(l.modes) - 1] = action.mode
+    }
 }
 
 // Reads the next character and associates it with location on stack.
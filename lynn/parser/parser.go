@@ -12,13 +12,10 @@ type productionData struct {
     left, length   int
     visitor        string
     aliases        map[string]int
+    dropped        []bool // Children omitted from the node, nil if no children are dropped
+    hoist          int    // Index of the child that replaces the node, -1 if no child is hoisted
 }
 
-// Parse table entry struct. Holds action entries and goto table for a specific state.
-type tableEntry struct {
-    actions map[int]actionEntry
-    gotos   map[int]int
-}
 // Parse table action entry struct. Holds action type and integer parameter.
 type actionEntry struct {
     actionType, value int // For shift actions, value represents a state identifier, for reduce actions, a production identifier
@@ -32,105 +29,638 @@ type ParseTreeNode struct {
     Start, End Location
     data       *productionData
 }
+// Ambiguity node struct. Generated by GLR parsers where part of the input has multiple derivations, holds each derivation.
+type AmbiguityNode struct {
+    Alternatives []ParseTreeChild
+    Start, End   Location
+}
 
 var productions = []productionData {
-    { 2, 3, 2, "", nil },
-    { 0, 3, 0, "", nil },
-    { 0, 0, 1, "grammar", map[string]int { "stmt": 0 } },
-    { 0, 1, 5, "ruleStmt", map[string]int { "RULE": 0, "IDENTIFIER": 1, "expr": 3 } },
-    { 1, 5, 1, "", nil },
-    { 1, 5, 1, "", nil },
-    { 0, 4, 2, "", map[string]int { "a": 1 } },
-    { 3, 4, 0, "", nil },
-    { 0, 1, 4, "precedenceStmt", map[string]int { "PRECEDENCE": 0, "IDENTIFIER": 1, "v": 2 } },
-    { 0, 7, 2, "", map[string]int { "SKIP": 1 } },
-    { 3, 7, 0, "", nil },
-    { 0, 6, 3, "", map[string]int { "s": 2, "expr": 1 } },
-    { 3, 6, 0, "", nil },
-    { 0, 1, 4, "tokenStmt", map[string]int { "v": 2, "TOKEN": 0, "IDENTIFIER": 1 } },
-    { 0, 1, 5, "fragmentStmt", map[string]int { "FRAGMENT": 0, "IDENTIFIER": 1, "expr": 3 } },
-    { 0, 1, 2, "stmt", nil },
-    { 0, 2, 3, "unionExpr", map[string]int { "l": 0, "r": 2 } },
-    { 0, 8, 2, "", map[string]int { "IDENTIFIER": 1 } },
-    { 3, 8, 0, "", nil },
-    { 0, 10, 4, "labelExpr", map[string]int { "p": 3, "expr": 0, "IDENTIFIER": 2 } },
-    { 0, 11, 2, "concatExpr", map[string]int { "l": 0, "r": 1 } },
-    { 0, 12, 3, "aliasExpr", map[string]int { "IDENTIFIER": 0, "expr": 2 } },
-    { 1, 9, 1, "", nil },
-    { 1, 9, 1, "", nil },
-    { 1, 9, 1, "", nil },
-    { 0, 13, 2, "quantifierExpr", map[string]int { "op": 1, "expr": 0 } },
-    { 0, 13, 3, "groupExpr", map[string]int { "expr": 1 } },
-    { 0, 13, 1, "identifierExpr", map[string]int { "IDENTIFIER": 0 } },
-    { 0, 13, 1, "stringExpr", map[string]int { "STRING": 0 } },
-    { 0, 13, 1, "classExpr", map[string]int { "CLASS": 0 } },
-    { 0, 13, 1, "errorExpr", map[string]int { "ERROR": 0 } },
-    { 0, 13, 1, "anyExpr", nil },
-    { 1, 2, 1, "", nil },
-    { 1, 10, 1, "", nil },
-    { 1, 11, 1, "", nil },
-    { 1, 12, 1, "", nil },
+    { 2, 4, 2, "", nil, nil, -1 },
+    { 0, 4, 0, "", nil, nil, -1 },
+    { 0, 0, 1, "grammar", map[string]int { "stmt": 0 }, nil, -1 },
+    { 1, 5, 1, "", nil, nil, -1 },
+    { 3, 5, 0, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 6, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 8, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 1, 11, 1, "", nil, nil, -1 },
+    { 0, 10, 2, "", map[string]int { "id": 1 }, nil, -1 },
+    { 2, 9, 2, "", nil, nil, -1 },
+    { 0, 9, 0, "", nil, nil, -1 },
+    { 0, 7, 4, "", map[string]int { "id": 1 }, nil, -1 },
+    { 3, 7, 0, "", nil, nil, -1 },
+    { 0, 1, 7, "ruleStmt", map[string]int { "RULE": 1, "expr": 5, "i": 0, "id": 2, "p": 3 }, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 12, 1, "", nil, nil, -1 },
+    { 1, 14, 1, "", nil, nil, -1 },
+    { 1, 14, 1, "", nil, nil, -1 },
+    { 1, 14, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 17, 1, "", nil, nil, -1 },
+    { 1, 16, 1, "", nil, nil, -1 },
+    { 1, 16, 1, "", nil, nil, -1 },
+    { 2, 15, 2, "", nil, nil, -1 },
+    { 0, 15, 0, "", nil, nil, -1 },
+    { 0, 13, 3, "", map[string]int { "a": 1, "t": 2 }, nil, -1 },
+    { 3, 13, 0, "", nil, nil, -1 },
+    { 0, 1, 4, "precedenceStmt", map[string]int { "PRECEDENCE": 0, "id": 1, "v": 2 }, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 1, 18, 1, "", nil, nil, -1 },
+    { 0, 22, 2, "", map[string]int { "action": 1 }, nil, -1 },
+    { 2, 21, 2, "", nil, nil, -1 },
+    { 0, 21, 0, "", nil, nil, -1 },
+    { 0, 20, 3, "", map[string]int { "action": 1 }, nil, -1 },
+    { 3, 20, 0, "", nil, nil, -1 },
+    { 0, 19, 3, "", map[string]int { "a": 2, "expr": 1 }, nil, -1 },
+    { 3, 19, 0, "", nil, nil, -1 },
+    { 0, 1, 4, "tokenStmt", map[string]int { "TOKEN": 0, "id": 1, "v": 2 }, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 1, 23, 1, "", nil, nil, -1 },
+    { 0, 1, 5, "fragmentStmt", map[string]int { "FRAGMENT": 0, "expr": 3, "id": 1 }, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 1, 24, 1, "", nil, nil, -1 },
+    { 0, 1, 3, "modeStmt", map[string]int { "MODE": 0, "id": 1 }, nil, -1 },
+    { 0, 1, 3, "importStmt", map[string]int { "IMPORT": 0, "STRING": 1 }, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 1, 25, 1, "", nil, nil, -1 },
+    { 0, 1, 3, "startStmt", map[string]int { "START": 0, "id": 1 }, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 1, 26, 1, "", nil, nil, -1 },
+    { 0, 1, 3, "optionStmt", map[string]int { "OPTION": 0, "id": 1 }, nil, -1 },
+    { 0, 1, 2, "stmt", nil, nil, -1 },
+    { 0, 2, 1, "skipAction", map[string]int { "SKIP": 0 }, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 1, 27, 1, "", nil, nil, -1 },
+    { 0, 2, 4, "pushModeAction", map[string]int { "PUSH_MODE": 0, "id": 2 }, nil, -1 },
+    { 0, 2, 1, "popModeAction", map[string]int { "POP_MODE": 0 }, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 1, 28, 1, "", nil, nil, -1 },
+    { 0, 2, 4, "modeAction", map[string]int { "MODE": 0, "id": 2 }, nil, -1 },
+    { 0, 2, 1, "nocaseAction", map[string]int { "NOCASE": 0 }, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 1, 29, 1, "", nil, nil, -1 },
+    { 0, 2, 4, "channelAction", map[string]int { "CHANNEL": 0, "id": 2 }, nil, -1 },
+    { 0, 3, 3, "unionExpr", map[string]int { "l": 0, "r": 2 }, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 30, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 1, 32, 1, "", nil, nil, -1 },
+    { 0, 31, 2, "", map[string]int { "id": 1 }, nil, -1 },
+    { 3, 31, 0, "", nil, nil, -1 },
+    { 0, 42, 4, "labelExpr", map[string]int { "expr": 0, "id": 2, "p": 3 }, nil, -1 },
+    { 0, 43, 2, "concatExpr", map[string]int { "l": 0, "r": 1 }, nil, -1 },
+    { 0, 44, 3, "differenceExpr", map[string]int { "l": 0, "r": 2 }, nil, -1 },
+    { 0, 44, 3, "intersectionExpr", map[string]int { "l": 0, "r": 2 }, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 1, 33, 1, "", nil, nil, -1 },
+    { 0, 45, 3, "aliasExpr", map[string]int { "expr": 2, "id": 0 }, nil, -1 },
+    { 0, 45, 2, "dropExpr", map[string]int { "expr": 1 }, nil, -1 },
+    { 0, 45, 2, "hoistExpr", map[string]int { "expr": 1 }, nil, -1 },
+    { 1, 34, 1, "", nil, nil, -1 },
+    { 1, 34, 1, "", nil, nil, -1 },
+    { 0, 46, 3, "separatedExpr", map[string]int { "l": 0, "op": 1, "r": 2 }, nil, -1 },
+    { 1, 35, 1, "", nil, nil, -1 },
+    { 1, 35, 1, "", nil, nil, -1 },
+    { 1, 35, 1, "", nil, nil, -1 },
+    { 0, 47, 2, "quantifierExpr", map[string]int { "expr": 0, "op": 1 }, nil, -1 },
+    { 1, 37, 1, "", nil, nil, -1 },
+    { 3, 37, 0, "", nil, nil, -1 },
+    { 0, 36, 2, "", map[string]int { "max": 1 }, nil, -1 },
+    { 3, 36, 0, "", nil, nil, -1 },
+    { 0, 47, 5, "repeatExpr", map[string]int { "expr": 0, "m": 3, "min": 2 }, nil, -1 },
+    { 0, 47, 3, "groupExpr", map[string]int { "expr": 1 }, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 1, 38, 1, "", nil, nil, -1 },
+    { 0, 40, 2, "", map[string]int { "expr": 1 }, nil, -1 },
+    { 2, 39, 2, "", nil, nil, -1 },
+    { 0, 39, 0, "", nil, nil, -1 },
+    { 0, 47, 5, "templateExpr", map[string]int { "a": 3, "expr": 2, "id": 0 }, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 1, 41, 1, "", nil, nil, -1 },
+    { 0, 47, 1, "identifierExpr", map[string]int { "id": 0 }, nil, -1 },
+    { 0, 47, 1, "stringExpr", map[string]int { "STRING": 0 }, nil, -1 },
+    { 0, 47, 1, "nocaseStringExpr", map[string]int { "ISTRING": 0 }, nil, -1 },
+    { 0, 47, 1, "classExpr", map[string]int { "CLASS": 0 }, nil, -1 },
+    { 0, 47, 1, "errorExpr", map[string]int { "ERROR": 0 }, nil, -1 },
+    { 0, 47, 1, "anyExpr", nil, nil, -1 },
+    { 1, 3, 1, "", nil, nil, -1 },
+    { 1, 42, 1, "", nil, nil, -1 },
+    { 1, 43, 1, "", nil, nil, -1 },
+    { 1, 44, 1, "", nil, nil, -1 },
+    { 1, 45, 1, "", nil, nil, -1 },
+    { 1, 46, 1, "", nil, nil, -1 },
+}
+// Parse table, compressed using row displacement. The entry of a row at a column is found at the row's base plus the column,
+// and only belongs to the row if its check value is the row's base. Action rows are indexed by state and token type (offset
+// by one for the error terminal), and goto rows by non-terminal and state.
+var actionBase = []int32 {
+    1942, 2, 1982, 2377, 2400, 2413, 2433, 30, 2446, 1, 2466, 57, 2488, 3, 1565, 1605, 1645, 1685, 1725, 1765, 1805, 1827, 1828, 1845,
+    1876, 1907, 1916, 1917, 1929, 1947, 1956, 1959, 1969, 2002, 2005, 2006, 2009, 2027, 95, 135, 175, 215, 255, 295, 335, 375, 415, 425,
+    455, 465, 82, 122, 162, 202, 242, 282, 322, 362, 402, 442, 491, 497, 507, 522, 537, 547, 571, 577, 587, 602, 606, 611,
+    617, 627, 642, 646, 651, 657, 667, 682, 686, 691, 697, 707, 722, 726, 731, 2509, 2526, 1512, 737, 1880, 747, 1880, 2547, 2568,
+    2589, 2610, 4, 44, 84, 124, 164, 204, 244, 284, 324, 364, 404, 444, 2040, 2053, 2076, 2089, 2631, 0, 880, 40, 80, 120,
+    160, 200, 240, 280, 320, 360, 1880, 1880, 920, 1880, 400, 960, 1000, 1040, 1442, 15, 755, 1080, 1873, 1840, 1520, 1560, 1480, 1120,
+    2652, 486, 2669, 764, 2112, 1600, 1640, 1443, 1880, 1908, 771, 1880, 1880, 2690, 1680, 1880, 1880, 1920, 1960, 2000, 1160, 1200, 1240, 766,
+    1280, 2711, 1466, 1546, 1558, 1586, 1598, 1626, 1638, 1666, 1678, 1706, 1718, 1746, 1880, 2125, 2148, 2161, 2184, 2197, 2220, 2233, 2256, 2269,
+    2292, 2305, 2328, 2341, 2364, 1320, 2950, 886, 784, 799, 926, 966, 803, 1006, 1720, 1517, 1992, 2804, 2818, 2832, 2846, 2860, 2874, 2888,
+    2902, 2916, 2930, 2944, 1760, 1800, 440, 480, 520, 560, 600, 640, 680, 720, 760, 800, 840, 1440, 525, 1758, 566, 2723, 2735, 2756,
+    1046, 1786, 2768, 2960, 1076, 805, 2789, 815, 1798, 2810, 823, 838, 842, 847, 853, 863, 878, 887, 893, 903, 918, 927, 933, 943,
+    958, 967, 973, 983, 998, 1007, 1013, 1023, 1038, 1047, 1053, 1063, 1082, 1087, 1093, 1103, 1118, 1127, 1133, 1143, 1158, 1167, 1908, 1126,
+    1880, 1360, 1838, 2966, 2976, 2982, 2992, 2998, 3008, 3014, 3024, 3030, 3040, 3046, 3056, 1171, 1181, 1400, 1986, 1990, 2041, 2077, 2113, 2149,
+    2185, 2221, 2257, 2293, 2329, 2365, 1166, 1206, 1246, 1286, 1518,
+}
+var actionCheck = []int32 {
+    -1, -1, -1, -1, 1, -1, 3, -1, -1, 0, 0, -1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 15, 0, 0, 0, 4, 0, 0, 0, 0, 4, 0, 0,
+    0, 40, 40, 2, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40,
+    40, 40, 40, 40, 30, 40, 40, 40, 44, 40, 40, 40, 40, 44, 40, 40, 40, 80, 80, 57, 80, 80, 80, 80,
+    80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 82, 80, 80, 80,
+    84, 80, 80, 80, 80, 84, 80, 80, 80, 120, 120, 95, 120, 120, 120, 120, 120, 120, 120, 120, 120, 120, 120, 120,
+    120, 120, 120, 120, 120, 120, 120, 120, 120, 120, 120, 120, 122, 120, 120, 120, 124, 120, 120, 120, 120, 124, 120, 120,
+    120, 160, 160, 135, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160,
+    160, 160, 160, 160, 162, 160, 160, 160, 164, 160, 160, 160, 160, 164, 160, 160, 160, 200, 200, 175, 200, 200, 200, 200,
+    200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 200, 202, 200, 200, 200,
+    204, 200, 200, 200, 200, 204, 200, 200, 200, 240, 240, 215, 240, 240, 240, 240, 240, 240, 240, 240, 240, 240, 240, 240,
+    240, 240, 240, 240, 240, 240, 240, 240, 240, 240, 240, 240, 242, 240, 240, 240, 244, 240, 240, 240, 240, 244, 240, 240,
+    240, 280, 280, 255, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280, 280,
+    280, 280, 280, 280, 282, 280, 280, 280, 284, 280, 280, 280, 280, 284, 280, 280, 280, 320, 320, 295, 320, 320, 320, 320,
+    320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 320, 322, 320, 320, 320,
+    324, 320, 320, 320, 320, 324, 320, 320, 320, 360, 360, 335, 360, 360, 360, 360, 360, 360, 360, 360, 360, 360, 360, 360,
+    360, 360, 360, 360, 360, 360, 360, 360, 360, 360, 360, 360, 362, 360, 360, 360, 364, 360, 360, 360, 360, 364, 360, 360,
+    360, 400, 400, 375, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400, 400,
+    400, 400, 400, 400, 402, 400, 400, 400, 404, 400, 400, 400, 400, 404, 400, 400, 400, 440, 440, 415, 440, 440, 440, 440,
+    440, 440, 440, 440, 440, 425, 440, 440, 440, 440, 440, 440, 440, 440, 440, 440, 440, 440, 440, 440, 442, 440, 440, 440,
+    444, 440, 440, 440, 440, 444, 440, 440, 440, 480, 480, 455, 480, 480, 480, 480, 480, 480, 480, 480, 480, 465, 480, 480,
+    480, 480, 480, 480, 480, 480, 480, 480, 480, 480, 480, 480, 486, 480, 480, 480, 486, 480, 480, 480, 480, 491, 480, 480,
+    480, 520, 520, 497, 520, 520, 520, 520, 520, 520, 520, 520, 520, 507, 520, 520, 520, 520, 520, 520, 520, 520, 520, 520,
+    520, 520, 520, 520, 522, 520, 520, 520, 525, 520, 520, 520, 520, 525, 520, 520, 520, 560, 560, 537, 560, 560, 560, 560,
+    560, 560, 560, 560, 560, 547, 560, 560, 560, 560, 560, 560, 560, 560, 560, 560, 560, 560, 560, 560, 566, 560, 560, 560,
+    566, 560, 560, 560, 560, 571, 560, 560, 560, 600, 600, 577, 600, 600, 600, 600, 600, 600, 600, 600, 600, 587, 600, 600,
+    600, 600, 600, 600, 600, 600, 600, 600, 600, 600, 600, 600, 602, 600, 600, 600, 606, 600, 600, 600, 600, 611, 600, 600,
+    600, 640, 640, 617, 640, 640, 640, 640, 640, 640, 640, 640, 640, 627, 640, 640, 640, 640, 640, 640, 640, 640, 640, 640,
+    640, 640, 640, 640, 642, 640, 640, 640, 646, 640, 640, 640, 640, 651, 640, 640, 640, 680, 680, 657, 680, 680, 680, 680,
+    680, 680, 680, 680, 680, 667, 680, 680, 680, 680, 680, 680, 680, 680, 680, 680, 680, 680, 680, 680, 682, 680, 680, 680,
+    686, 680, 680, 680, 680, 691, 680, 680, 680, 720, 720, 697, 720, 720, 720, 720, 720, 720, 720, 720, 720, 707, 720, 720,
+    720, 720, 720, 720, 720, 720, 720, 720, 720, 720, 720, 720, 722, 720, 720, 720, 726, 720, 720, 720, 720, 731, 720, 720,
+    720, 760, 760, 737, 760, 760, 760, 760, 760, 760, 760, 760, 760, 747, 760, 760, 760, 760, 760, 760, 760, 760, 760, 760,
+    760, 760, 760, 760, 755, 760, 760, 760, 764, 760, 760, 760, 760, 771, 760, 760, 760, 800, 800, 766, 800, 800, 800, 800,
+    800, 800, 800, 800, 800, 784, 800, 800, 800, 800, 800, 800, 800, 800, 800, 800, 800, 800, 800, 800, 799, 800, 800, 800,
+    803, 800, 800, 800, 800, 805, 800, 800, 800, 840, 840, 815, 840, 840, 840, 840, 840, 840, 840, 840, 840, 823, 840, 840,
+    840, 840, 840, 840, 840, 840, 840, 840, 840, 840, 840, 840, 838, 840, 840, 840, 842, 840, 840, 840, 840, 847, 840, 840,
+    840, 880, 880, 853, 880, 880, 880, 880, 880, 880, 880, 880, 880, 863, 880, 880, 880, 880, 880, 880, 880, 880, 880, 880,
+    880, 880, 880, 880, 878, 880, 880, 880, 886, 886, 880, 880, 880, 887, 880, 880, 880, 920, 920, 893, 920, 920, 920, 920,
+    920, 920, 920, 920, 920, 903, 920, 920, 920, 920, 920, 920, 920, 920, 920, 920, 920, 920, 920, 920, 918, 920, 920, 920,
+    926, 926, 920, 920, 920, 927, 920, 920, 920, 960, 960, 933, 960, 960, 960, 960, 960, 960, 960, 960, 960, 943, 960, 960,
+    960, 960, 960, 960, 960, 960, 960, 960, 960, 960, 960, 960, 958, 960, 960, 960, 966, 966, 960, 960, 960, 967, 960, 960,
+    960, 1000, 1000, 973, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 983, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000,
+    1000, 1000, 1000, 1000, 998, 1000, 1000, 1000, 1006, 1006, 1000, 1000, 1000, 1007, 1000, 1000, 1000, 1040, 1040, 1013, 1040, 1040, 1040, 1040,
+    1040, 1040, 1040, 1040, 1040, 1023, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1040, 1038, 1040, 1040, 1040,
+    1046, 1046, 1040, 1040, 1040, 1047, 1040, 1040, 1040, 1080, 1080, 1053, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1063, 1080, 1080,
+    1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1080, 1076, 1080, 1080, 1080, 1082, 1076, 1080, 1080, 1080, 1087, 1080, 1080,
+    1080, 1120, 1120, 1093, 1120, 1120, 1120, 1120, 1120, 1120, 1120, 1120, 1120, 1103, 1120, 1120, 1120, 1120, 1120, 1120, 1120, 1120, 1120, 1120,
+    1120, 1120, 1120, 1120, 1118, 1120, 1120, 1120, 1126, 1126, 1120, 1120, 1120, 1127, 1120, 1120, 1120, 1160, 1160, 1133, 1160, 1160, 1160, 1160,
+    1160, 1160, 1160, 1160, 1160, 1143, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1160, 1158, 1160, 1160, 1160,
+    1166, 1166, 1160, 1160, 1160, 1167, 1160, 1160, 1160, 1200, 1200, 1171, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1181, 1200, 1200,
+    1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, 1200, -1, 1200, 1200, 1200, 1206, 1206, 1200, 1200, 1200, -1, 1200, 1200,
+    1200, 1240, 1240, -1, 1240, 1240, 1240, 1240, 1240, 1240, 1240, 1240, 1240, -1, 1240, 1240, 1240, 1240, 1240, 1240, 1240, 1240, 1240, 1240,
+    1240, 1240, 1240, 1240, -1, 1240, 1240, 1240, 1246, 1246, 1240, 1240, 1240, -1, 1240, 1240, 1240, 1280, 1280, -1, 1280, 1280, 1280, 1280,
+    1280, 1280, 1280, 1280, 1280, -1, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, 1280, -1, 1280, 1280, 1280,
+    1286, 1286, 1280, 1280, 1280, -1, 1280, 1280, 1280, 1320, 1320, -1, 1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, -1, 1320, 1320,
+    1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, 1320, -1, 1320, 1320, 1320, -1, -1, 1320, 1320, 1320, -1, 1320, 1320,
+    1320, 1360, 1360, -1, 1360, 1360, 1360, 1360, 1360, 1360, 1360, 1360, 1360, -1, 1360, 1360, 1360, 1360, 1360, 1360, 1360, 1360, 1360, 1360,
+    1360, 1360, 1360, 1360, -1, 1360, 1360, 1360, -1, -1, 1360, 1360, 1360, -1, 1360, 1360, 1360, 1400, 1400, -1, 1400, 1400, 1400, 1400,
+    1400, 1400, 1400, 1400, 1400, -1, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, 1400, -1, 1400, 1400, 1400,
+    -1, -1, 1400, 1400, 1400, -1, 1400, 1400, 1400, 1440, 1440, -1, 1440, 1440, 1440, 1440, 1440, 1440, 1440, 1440, 1440, -1, 1440, 1440,
+    1440, 1440, 1440, 1440, 1440, 1440, 1440, 1440, 1442, 1443, 1440, 1440, 1442, 1440, 1440, 1440, -1, 1443, 1440, 1440, 1440, 1442, 1440, 1440,
+    1440, 1480, 1480, -1, 1480, 1480, 1480, 1480, 1480, 1480, 1480, 1480, 1480, 1466, -1, 1480, 1480, 1480, 1480, -1, 1466, 1480, 1480, 1480,
+    1480, 1480, 1480, 1480, -1, 1480, 1480, 1512, 1512, 1512, 1480, 1480, 1480, -1, 1480, 1480, 1480, 1520, 1520, -1, 1520, 1520, 1520, 1520,
+    1520, 1520, 1520, 1520, 1520, -1, -1, 1520, 1520, 1520, 1520, 1517, 1518, 1520, 1520, 1520, 1517, 1518, 1520, 1520, -1, 1520, 1520, 1517,
+    1518, -1, 1520, 1520, 1520, -1, 1520, 1520, 1520, 1560, 1560, -1, 1560, 1560, 1560, 1560, 1560, 1560, 1560, 1560, 1560, 1546, -1, 1560,
+    1560, 1560, 1560, -1, 1546, 1560, 1560, 1560, -1, 1558, 1560, 1560, -1, 1560, 1560, 1565, 1558, 1565, 1560, 1560, 1560, -1, 1560, 1560,
+    1560, 1600, 1600, -1, 1600, 1600, 1600, 1600, 1600, 1600, 1600, 1600, 1600, 1586, -1, 1600, 1600, 1600, 1600, -1, 1586, 1600, 1600, 1600,
+    -1, 1598, 1600, 1600, -1, 1600, 1600, 1605, 1598, 1605, 1600, 1600, 1600, -1, 1600, 1600, 1600, 1640, 1640, -1, 1640, 1640, 1640, 1640,
+    1640, 1640, 1640, 1640, 1640, 1626, -1, 1640, 1640, 1640, 1640, -1, 1626, 1640, 1640, 1640, -1, 1638, 1640, 1640, -1, 1640, 1640, 1645,
+    1638, 1645, 1640, 1640, 1640, -1, 1640, 1640, 1640, 1680, 1680, -1, 1680, 1680, 1680, 1680, 1680, 1680, 1680, 1680, 1680, 1666, -1, 1680,
+    1680, 1680, 1680, -1, 1666, 1680, 1680, 1680, -1, 1678, 1680, 1680, -1, 1680, 1680, 1685, 1678, 1685, 1680, 1680, 1680, -1, 1680, 1680,
+    1680, 1720, 1720, -1, 1720, 1720, 1720, 1720, 1720, 1720, 1720, 1720, 1720, 1706, -1, 1720, 1720, 1720, 1720, -1, 1706, 1720, 1720, 1720,
+    -1, 1718, 1720, 1720, -1, 1720, 1720, 1725, 1718, 1725, 1720, 1720, 1720, -1, 1720, 1720, 1720, 1760, 1760, -1, 1760, 1760, 1760, 1760,
+    1760, 1760, 1760, 1760, 1760, 1746, -1, 1760, 1760, 1760, 1760, -1, 1746, 1760, 1760, 1760, -1, 1758, 1760, 1760, -1, 1760, 1760, 1765,
+    1758, 1765, 1760, 1760, 1760, -1, 1760, 1760, 1760, 1800, 1800, -1, 1800, 1800, 1800, 1800, 1800, 1800, 1800, 1800, 1800, 1786, -1, 1800,
+    1800, 1800, 1800, -1, 1786, 1800, 1800, 1800, -1, 1798, 1800, 1800, -1, 1800, 1800, 1805, 1798, 1805, 1800, 1800, 1800, -1, 1800, 1800,
+    1800, 1840, 1840, -1, 1840, 1840, 1840, 1840, 1840, 1840, 1840, 1840, 1840, 1827, 1828, 1827, 1828, 1840, 1840, -1, -1, 1840, 1840, 1840,
+    -1, 1838, 1840, 1840, -1, 1840, 1840, 1845, 1838, 1845, 1840, 1840, 1840, -1, 1840, 1840, 1840, 1880, 1880, -1, 1880, 1880, 1880, 1880,
+    1880, 1880, 1880, 1880, 1880, -1, -1, 1873, 1873, 1880, 1880, 1873, 1873, 1880, 1876, 1873, 1876, -1, -1, 1873, 1873, 1880, -1, 1908,
+    1908, 1908, 1908, 1908, 1880, 1908, 1880, 1880, 1880, 1920, 1920, -1, 1920, 1920, 1920, 1920, 1920, 1920, 1920, 1920, 1920, 1907, 1942, 1907,
+    -1, 1942, 1942, 1942, 1942, 1920, 1916, 1917, 1916, 1917, 1942, -1, -1, 1920, 1942, -1, 1942, 1942, 1942, 1929, 1920, 1929, 1920, 1920,
+    1920, 1960, 1960, -1, 1960, 1960, 1960, 1960, 1960, 1960, 1960, 1960, 1960, 1947, 1982, 1947, -1, 1982, 1982, 1982, 1982, 1960, 1956, 1942,
+    1956, 1959, 1982, 1959, -1, 1960, 1982, -1, 1982, 1982, 1982, 1969, 1960, 1969, 1960, 1960, 1960, 2000, 2000, -1, 2000, 2000, 2000, 2000,
+    2000, 2000, 2000, 2000, 2000, 1986, 1992, 1992, 1992, 1990, 1992, 1992, 1986, 2000, 1992, 1982, 1990, -1, 1992, 1992, 2002, 2000, 2002, 2005,
+    2006, 2005, 2006, 2009, 2000, 2009, 2000, 2000, 2000, 2040, -1, -1, 2040, 2040, 2040, 2040, 2040, 2040, 2040, 2040, 2040, 2027, 2053, 2027,
+    -1, 2053, 2053, 2053, 2053, 2053, 2053, 2053, 2053, 2053, 2040, -1, 2041, -1, -1, -1, -1, -1, -1, 2041, 2040, 2076, 2040, 2053,
+    2076, 2076, 2076, 2076, 2076, 2076, 2076, 2076, 2076, 2053, 2089, 2053, -1, 2089, 2089, 2089, 2089, 2089, 2089, 2089, 2089, 2089, 2076, -1,
+    2077, -1, -1, -1, -1, -1, -1, 2077, 2076, 2112, 2076, 2089, 2112, 2112, 2112, 2112, 2112, 2112, 2112, 2112, 2112, 2089, 2125, 2089,
+    -1, 2125, 2125, 2125, 2125, 2125, 2125, 2125, 2125, 2125, 2112, -1, 2113, -1, -1, -1, -1, -1, -1, 2113, 2112, 2148, 2112, 2125,
+    2148, 2148, 2148, 2148, 2148, 2148, 2148, 2148, 2148, 2125, 2161, 2125, -1, 2161, 2161, 2161, 2161, 2161, 2161, 2161, 2161, 2161, 2148, -1,
+    2149, -1, -1, -1, -1, -1, -1, 2149, 2148, 2184, 2148, 2161, 2184, 2184, 2184, 2184, 2184, 2184, 2184, 2184, 2184, 2161, 2197, 2161,
+    -1, 2197, 2197, 2197, 2197, 2197, 2197, 2197, 2197, 2197, 2184, -1, 2185, -1, -1, -1, -1, -1, -1, 2185, 2184, 2220, 2184, 2197,
+    2220, 2220, 2220, 2220, 2220, 2220, 2220, 2220, 2220, 2197, 2233, 2197, -1, 2233, 2233, 2233, 2233, 2233, 2233, 2233, 2233, 2233, 2220, -1,
+    2221, -1, -1, -1, -1, -1, -1, 2221, 2220, 2256, 2220, 2233, 2256, 2256, 2256, 2256, 2256, 2256, 2256, 2256, 2256, 2233, 2269, 2233,
+    -1, 2269, 2269, 2269, 2269, 2269, 2269, 2269, 2269, 2269, 2256, -1, 2257, -1, -1, -1, -1, -1, -1, 2257, 2256, 2292, 2256, 2269,
+    2292, 2292, 2292, 2292, 2292, 2292, 2292, 2292, 2292, 2269, 2305, 2269, -1, 2305, 2305, 2305, 2305, 2305, 2305, 2305, 2305, 2305, 2292, -1,
+    2293, -1, -1, -1, -1, -1, -1, 2293, 2292, 2328, 2292, 2305, 2328, 2328, 2328, 2328, 2328, 2328, 2328, 2328, 2328, 2305, 2341, 2305,
+    -1, 2341, 2341, 2341, 2341, 2341, 2341, 2341, 2341, 2341, 2328, -1, 2329, -1, -1, -1, -1, -1, -1, 2329, 2328, 2364, 2328, 2341,
+    2364, 2364, 2364, 2364, 2364, 2364, 2364, 2364, 2364, 2341, 2377, 2341, -1, 2377, 2377, 2377, 2377, 2377, 2377, 2377, 2377, 2377, 2364, -1,
+    2365, -1, -1, -1, -1, -1, -1, 2365, 2364, 2400, 2364, -1, 2400, 2400, 2400, 2400, 2400, 2400, 2400, 2400, 2400, 2377, 2413, -1,
+    -1, 2413, 2413, 2413, 2413, 2413, 2413, 2413, 2413, 2413, -1, -1, -1, -1, -1, -1, -1, -1, 2433, -1, 2400, 2433, 2433, 2433,
+    2433, 2433, 2433, 2433, 2433, 2433, -1, 2446, -1, 2413, 2446, 2446, 2446, 2446, 2446, 2446, 2446, 2446, 2446, -1, -1, -1, -1, -1,
+    -1, -1, -1, 2466, -1, 2433, 2466, 2466, 2466, 2466, 2466, 2466, 2466, 2466, 2466, -1, 2488, -1, 2446, 2488, 2488, 2488, 2488, -1,
+    -1, -1, -1, -1, 2488, -1, -1, -1, 2488, -1, 2488, 2488, 2488, 2509, 2466, -1, 2509, 2509, 2509, 2509, -1, -1, -1, -1,
+    -1, 2509, -1, -1, -1, 2509, -1, 2509, 2509, 2509, -1, -1, -1, -1, -1, 2526, -1, 2488, 2526, 2526, 2526, 2526, 2526, 2526,
+    2526, 2526, 2526, 2547, -1, -1, 2547, 2547, 2547, 2547, -1, -1, -1, -1, 2509, 2547, -1, -1, -1, 2547, -1, 2547, 2547, 2547,
+    2568, -1, 2526, 2568, 2568, 2568, 2568, -1, -1, -1, -1, -1, 2568, -1, -1, -1, 2568, -1, 2568, 2568, 2568, 2589, -1, -1,
+    2589, 2589, 2589, 2589, 2547, -1, -1, -1, -1, 2589, -1, -1, -1, 2589, -1, 2589, 2589, 2589, 2610, -1, -1, 2610, 2610, 2610,
+    2610, 2568, -1, -1, -1, -1, 2610, -1, -1, -1, 2610, -1, 2610, 2610, 2610, 2631, -1, -1, 2631, 2631, 2631, 2631, 2589, -1,
+    -1, -1, -1, 2631, -1, -1, -1, 2631, -1, 2631, 2631, 2631, 2652, -1, -1, 2652, 2652, 2652, 2652, 2610, -1, -1, -1, -1,
+    2652, -1, -1, -1, 2652, -1, 2652, 2652, 2652, -1, -1, -1, -1, -1, 2669, -1, 2631, 2669, 2669, 2669, 2669, 2669, 2669, 2669,
+    2669, 2669, -1, -1, -1, -1, -1, -1, -1, -1, -1, 2690, -1, 2652, 2690, 2690, 2690, 2690, 2690, 2690, 2690, 2690, 2690, 2711,
+    -1, 2669, 2711, 2711, 2711, 2711, -1, -1, -1, -1, -1, 2711, -1, -1, -1, 2711, -1, 2711, 2711, 2711, 2723, -1, 2690, 2723,
+    2723, 2723, 2723, 2723, 2723, 2723, 2723, 2723, 2735, -1, -1, 2735, 2735, 2735, 2735, 2735, 2735, 2735, 2735, 2735, -1, -1, -1, -1,
+    2711, -1, -1, -1, -1, 2756, -1, 2723, 2756, 2756, 2756, 2756, 2756, 2756, 2756, 2756, 2756, 2768, -1, 2735, 2768, 2768, 2768, 2768,
+    2768, 2768, 2768, 2768, 2768, -1, -1, -1, -1, -1, -1, -1, -1, -1, 2789, -1, 2756, 2789, 2789, 2789, 2789, 2789, 2789, 2789,
+    2789, 2789, 2810, -1, 2768, 2810, 2810, 2810, 2810, -1, -1, -1, -1, -1, 2810, -1, -1, -1, 2810, -1, 2810, 2810, 2810, -1,
+    -1, 2789, 2804, 2804, 2804, -1, 2804, 2804, -1, -1, 2804, -1, -1, -1, 2804, 2804, 2818, 2818, 2818, -1, 2818, 2818, -1, -1,
+    2818, -1, -1, 2810, 2818, 2818, 2832, 2832, 2832, -1, 2832, 2832, -1, -1, 2832, -1, -1, -1, 2832, 2832, 2846, 2846, 2846, -1,
+    2846, 2846, -1, -1, 2846, -1, -1, -1, 2846, 2846, 2860, 2860, 2860, -1, 2860, 2860, -1, -1, 2860, -1, -1, -1, 2860, 2860,
+    2874, 2874, 2874, -1, 2874, 2874, -1, -1, 2874, -1, -1, -1, 2874, 2874, 2888, 2888, 2888, -1, 2888, 2888, -1, -1, 2888, -1,
+    -1, -1, 2888, 2888, 2902, 2902, 2902, -1, 2902, 2902, -1, -1, 2902, -1, -1, -1, 2902, 2902, 2916, 2916, 2916, -1, 2916, 2916,
+    -1, -1, 2916, -1, -1, -1, 2916, 2916, 2930, 2930, 2930, -1, 2930, 2930, -1, -1, 2930, -1, -1, -1, 2930, 2930, 2944, 2944,
+    2944, -1, 2944, 2944, 2950, 2950, 2944, -1, 2950, 2950, 2944, 2944, 2950, -1, 2960, 2960, 2950, 2950, 2960, 2960, 2966, 2966, 2960, -1,
+    2966, 2966, 2960, 2960, 2966, -1, 2976, 2976, 2966, 2966, 2976, 2976, 2982, 2982, 2976, -1, 2982, 2982, 2976, 2976, 2982, -1, 2992, 2992,
+    2982, 2982, 2992, 2992, 2998, 2998, 2992, -1, 2998, 2998, 2992, 2992, 2998, -1, 3008, 3008, 2998, 2998, 3008, 3008, 3014, 3014, 3008, -1,
+    3014, 3014, 3008, 3008, 3014, -1, 3024, 3024, 3014, 3014, 3024, 3024, 3030, 3030, 3024, -1, 3030, 3030, 3024, 3024, 3030, -1, 3040, 3040,
+    3030, 3030, 3040, 3040, 3046, 3046, 3040, -1, 3046, 3046, 3040, 3040, 3046, -1, 3056, 3056, 3046, 3046, 3056, 3056, -1, -1, 3056, -1,
+    -1, -1, 3056, 3056,
+}
+var actionValue = []int32 { // Action type in the lowest two bits, followed by the action value
+    0, 0, 0, 0, 13, 0, 352, 0, 0, 1025, 1025, 0, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 857, 1025, 1025,
+    1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 620, 1025, 1025, 1025, 25, 965, 1025, 1025, 1025, 25, 1025, 1025,
+    1025, 1029, 1029, 2, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 861, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029,
+    1029, 1029, 1029, 1029, 248, 1029, 1029, 1029, 29, 969, 1029, 1029, 1029, 29, 1029, 1029, 1029, 1033, 1033, 348, 1033, 1033, 1033, 1033,
+    1033, 1033, 1033, 1033, 1033, 865, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 433, 1033, 1033, 1033,
+    33, 973, 1033, 1033, 1033, 33, 1033, 1033, 1033, 1037, 1037, 385, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 869, 1037, 1037,
+    1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 437, 1037, 1037, 1037, 37, 977, 1037, 1037, 1037, 37, 1037, 1037,
+    1037, 1041, 1041, 389, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 873, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041,
+    1041, 1041, 1041, 1041, 441, 1041, 1041, 1041, 41, 981, 1041, 1041, 1041, 41, 1041, 1041, 1041, 1045, 1045, 393, 1045, 1045, 1045, 1045,
+    1045, 1045, 1045, 1045, 1045, 877, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 445, 1045, 1045, 1045,
+    45, 985, 1045, 1045, 1045, 45, 1045, 1045, 1045, 1049, 1049, 397, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 881, 1049, 1049,
+    1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 449, 1049, 1049, 1049, 49, 989, 1049, 1049, 1049, 49, 1049, 1049,
+    1049, 1053, 1053, 401, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 885, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053,
+    1053, 1053, 1053, 1053, 453, 1053, 1053, 1053, 53, 993, 1053, 1053, 1053, 53, 1053, 1053, 1053, 1057, 1057, 405, 1057, 1057, 1057, 1057,
+    1057, 1057, 1057, 1057, 1057, 889, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 457, 1057, 1057, 1057,
+    57, 997, 1057, 1057, 1057, 57, 1057, 1057, 1057, 1061, 1061, 409, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 893, 1061, 1061,
+    1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 461, 1061, 1061, 1061, 61, 1001, 1061, 1061, 1061, 61, 1061, 1061,
+    1061, 1021, 1021, 413, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 853, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021,
+    1021, 1021, 1021, 1021, 465, 1021, 1021, 1021, 21, 961, 1021, 1021, 1021, 21, 1021, 1021, 1021, 1025, 1025, 417, 1025, 1025, 1025, 1025,
+    1025, 1025, 1025, 1025, 1025, 421, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 1025, 469, 1025, 1025, 1025,
+    169, 965, 1025, 1025, 1025, 584, 1025, 1025, 1025, 1029, 1029, 381, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 372, 1029, 1029,
+    1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 1029, 608, 1029, 1029, 1029, 676, 969, 1029, 1029, 1029, 429, 1029, 1029,
+    1029, 1033, 1033, 376, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 380, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033, 1033,
+    1033, 1033, 1033, 1033, 485, 1033, 1033, 1033, 976, 973, 1033, 1033, 1033, 949, 1033, 1033, 1033, 1037, 1037, 489, 1037, 1037, 1037, 1037,
+    1037, 1037, 1037, 1037, 1037, 493, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 1037, 608, 1037, 1037, 1037,
+    996, 977, 1037, 1037, 1037, 497, 1037, 1037, 1037, 1041, 1041, 501, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 505, 1041, 1041,
+    1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 1041, 509, 1041, 1041, 1041, 513, 981, 1041, 1041, 1041, 517, 1041, 1041,
+    1041, 1045, 1045, 521, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 481, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045, 1045,
+    1045, 1045, 1045, 1045, 384, 1045, 1045, 1045, 533, 985, 1045, 1045, 1045, 537, 1045, 1045, 1045, 1049, 1049, 541, 1049, 1049, 1049, 1049,
+    1049, 1049, 1049, 1049, 1049, 545, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 1049, 549, 1049, 1049, 1049,
+    553, 989, 1049, 1049, 1049, 557, 1049, 1049, 1049, 1053, 1053, 561, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 565, 1053, 1053,
+    1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 1053, 569, 1053, 1053, 1053, 529, 993, 1053, 1053, 1053, 388, 1053, 1053,
+    1053, 1057, 1057, 456, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 576, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057, 1057,
+    1057, 1057, 1057, 1057, 624, 1057, 1057, 1057, 728, 997, 1057, 1057, 1057, 369, 1057, 1057, 1057, 1061, 1061, 936, 1061, 1061, 1061, 1061,
+    1061, 1061, 1061, 1061, 1061, 948, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 1061, 952, 1061, 1061, 1061,
+    956, 1001, 1061, 1061, 1061, 1220, 1061, 1061, 1061, 1021, 1021, 165, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 641, 1021, 1021,
+    1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 1021, 645, 1021, 1021, 1021, 649, 961, 1021, 1021, 1021, 653, 1021, 1021,
+    1021, 1081, 1081, 657, 1081, 1081, 1081, 1081, 1081, 1081, 1081, 1081, 1081, 661, 1081, 1081, 1081, 1081, 1081, 1081, 1081, 1081, 1081, 1081,
+    1081, 1081, 1081, 1081, 665, 1081, 1081, 1081, 581, 581, 1081, 1081, 1081, 669, 1081, 1081, 1081, 1085, 1085, 673, 1085, 1085, 1085, 1085,
+    1085, 1085, 1085, 1085, 1085, 677, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 1085, 637, 1085, 1085, 1085,
+    633, 633, 1085, 1085, 1085, 1272, 1085, 1085, 1085, 1069, 1069, 589, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 593, 1069, 1069,
+    1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 1069, 597, 1069, 1069, 1069, 685, 685, 1069, 1069, 1069, 601, 1069, 1069,
+    1069, 1073, 1073, 605, 1073, 1073, 1073, 1073, 1073, 1073, 1073, 1073, 1073, 609, 1073, 1073, 1073, 1073, 1073, 1073, 1073, 1073, 1073, 1073,
+    1073, 1073, 1073, 1073, 613, 1073, 1073, 1073, 357, 357, 1073, 1073, 1073, 617, 1073, 1073, 1073, 1077, 1077, 621, 1077, 1077, 1077, 1077,
+    1077, 1077, 1077, 1077, 1077, 625, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 1077, 585, 1077, 1077, 1077,
+    361, 1144, 1077, 1077, 1077, 1276, 1077, 1077, 1077, 1065, 1065, 693, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 697, 1065, 1065,
+    1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 1065, 941, 1065, 1065, 1065, 701, 1212, 1065, 1065, 1065, 705, 1065, 1065,
+    1065, 1109, 1109, 709, 1109, 1109, 1109, 1109, 1109, 1109, 1109, 1109, 1109, 713, 656, 1109, 1109, 1109, 1109, 660, 664, 1109, 1109, 1109,
+    1109, 1109, 1109, 1109, 717, 1109, 1109, 668, 353, 353, 1109, 1109, 1109, 721, 1109, 1109, 1109, 929, 929, 725, 929, 929, 929, 929,
+    929, 929, 929, 929, 929, 729, 929, 929, 929, 929, 929, 929, 929, 929, 929, 929, 929, 929, 929, 929, 689, 929, 929, 929,
+    681, 681, 929, 929, 929, 1280, 929, 929, 929, 925, 925, 937, 925, 925, 925, 925, 925, 925, 925, 925, 925, 945, 925, 925,
+    925, 925, 925, 925, 925, 925, 925, 925, 925, 925, 925, 925, 0, 925, 925, 925, 629, 629, 925, 925, 925, 0, 925, 925,
+    925, 921, 921, 0, 921, 921, 921, 921, 921, 921, 921, 921, 921, 0, 921, 921, 921, 921, 921, 921, 921, 921, 921, 921,
+    921, 921, 921, 921, 0, 921, 921, 921, 733, 733, 921, 921, 921, 0, 921, 921, 921, 933, 933, 0, 933, 933, 933, 933,
+    933, 933, 933, 933, 933, 0, 933, 933, 933, 933, 933, 933, 933, 933, 933, 933, 933, 933, 933, 933, 0, 933, 933, 933,
+    349, 349, 933, 933, 933, 0, 933, 933, 933, 957, 957, 0, 957, 957, 957, 957, 957, 957, 957, 957, 957, 0, 957, 957,
+    957, 957, 957, 957, 957, 957, 957, 957, 957, 957, 957, 957, 0, 957, 957, 957, 0, 0, 957, 957, 957, 0, 957, 957,
+    957, 1017, 1017, 0, 1017, 1017, 1017, 1017, 1017, 1017, 1017, 1017, 1017, 0, 1017, 1017, 1017, 1017, 1017, 1017, 1017, 1017, 1017, 1017,
+    1017, 1017, 1017, 1017, 0, 1017, 1017, 1017, 0, 0, 1017, 1017, 1017, 0, 1017, 1017, 1017, 953, 953, 0, 953, 953, 953, 953,
+    953, 953, 953, 953, 953, 0, 953, 953, 953, 953, 953, 953, 953, 953, 953, 953, 953, 953, 953, 953, 0, 953, 953, 953,
+    0, 0, 953, 953, 953, 0, 953, 953, 953, 917, 917, 0, 917, 917, 917, 917, 917, 917, 917, 917, 917, 0, 656, 917,
+    917, 917, 917, 660, 664, 917, 917, 917, 608, 608, 917, 917, 365, 917, 917, 668, 0, 788, 917, 917, 917, 612, 917, 917,
+    917, 1105, 1105, 0, 1105, 1105, 1105, 1105, 1105, 1105, 1105, 1105, 1105, 69, 0, 1105, 1105, 1105, 1105, 0, 69, 1105, 1105, 1105,
+    644, 648, 1105, 1105, 0, 1105, 1105, 440, 444, 448, 1105, 1105, 1105, 0, 1105, 1105, 1105, 1097, 1097, 0, 1097, 1097, 1097, 1097,
+    1097, 1097, 1097, 1097, 1097, 0, 0, 636, 640, 1097, 1097, 608, 608, 1097, 1097, 1097, 1013, 1005, 1097, 1097, 0, 1097, 1097, 1013,
+    1005, 0, 1097, 1097, 1097, 0, 1097, 1097, 1097, 1101, 1101, 0, 1101, 1101, 1101, 1101, 1101, 1101, 1101, 1101, 1101, 73, 0, 1101,
+    1101, 1101, 1101, 0, 73, 1101, 1101, 1101, 0, 77, 1101, 1101, 0, 1101, 1101, 181, 77, 181, 1101, 1101, 1101, 0, 1101, 1101,
+    1101, 901, 901, 0, 901, 901, 901, 901, 901, 901, 901, 901, 901, 81, 0, 901, 901, 901, 901, 0, 81, 901, 901, 901,
+    0, 85, 901, 901, 0, 901, 901, 185, 85, 185, 901, 901, 901, 0, 901, 901, 901, 905, 905, 0, 905, 905, 905, 905,
+    905, 905, 905, 905, 905, 89, 0, 905, 905, 905, 905, 0, 89, 905, 905, 905, 0, 93, 905, 905, 0, 905, 905, 189,
+    93, 189, 905, 905, 905, 0, 905, 905, 905, 841, 841, 0, 841, 841, 841, 841, 841, 841, 841, 841, 841, 97, 0, 636,
+    640, 841, 841, 0, 97, 841, 841, 841, 0, 101, 841, 841, 0, 841, 841, 193, 101, 193, 841, 841, 841, 0, 841, 841,
+    841, 897, 897, 0, 897, 897, 897, 897, 897, 897, 897, 897, 897, 105, 0, 897, 897, 897, 897, 0, 105, 897, 897, 897,
+    0, 65, 897, 897, 0, 897, 897, 197, 65, 197, 897, 897, 897, 0, 897, 897, 897, 845, 845, 0, 845, 845, 845, 845,
+    845, 845, 845, 845, 845, 161, 0, 845, 845, 845, 845, 0, 161, 845, 845, 845, 0, 984, 845, 845, 0, 845, 845, 201,
+    988, 201, 845, 845, 845, 0, 845, 845, 845, 849, 849, 0, 849, 849, 849, 849, 849, 849, 849, 849, 849, 1152, 0, 849,
+    849, 849, 849, 0, 1156, 849, 849, 849, 0, 157, 849, 849, 0, 849, 849, 205, 157, 205, 849, 849, 849, 0, 849, 849,
+    849, 460, 464, 0, 468, 472, 476, 480, 484, 488, 492, 496, 500, 209, 213, 209, 213, 504, 508, 0, 0, 512, 1093, 1093,
+    0, 1009, 1093, 1093, 0, 516, 1093, 217, 1009, 217, 1093, 1093, 520, 0, 524, 528, 532, 460, 464, 0, 468, 472, 476, 480,
+    484, 488, 492, 496, 500, 0, 0, 1089, 628, 504, 508, 1089, 1089, 512, 177, 1089, 177, 0, 0, 1089, 1089, 516, 0, 796,
+    800, 804, 808, 812, 520, 816, 524, 528, 532, 909, 909, 0, 909, 909, 909, 909, 909, 909, 909, 909, 909, 297, 5, 356,
+    0, 5, 5, 5, 5, 909, 309, 313, 309, 313, 5, 0, 0, 909, 5, 0, 5, 5, 5, 317, 909, 317, 909, 909,
+    909, 913, 913, 0, 913, 913, 913, 913, 913, 913, 913, 913, 913, 321, 44, 321, 0, 17, 12, 16, 20, 913, 325, 5,
+    325, 329, 24, 329, 0, 913, 28, 0, 32, 36, 40, 333, 913, 333, 913, 913, 913, 888, 464, 0, 892, 896, 900, 904,
+    908, 912, 916, 920, 924, 113, 745, 745, 745, 117, 745, 745, 113, 512, 745, 9, 117, 0, 745, 745, 337, 516, 337, 341,
+    345, 341, 345, 305, 928, 305, 524, 528, 532, 221, 0, 0, 221, 221, 221, 221, 221, 221, 221, 221, 221, 373, 225, 364,
+    0, 225, 225, 225, 225, 225, 225, 225, 225, 225, 221, 0, 121, 0, 0, 0, 0, 0, 0, 121, 221, 229, 221, 225,
+    229, 229, 229, 229, 229, 229, 229, 229, 229, 225, 289, 225, 0, 289, 289, 289, 289, 289, 289, 289, 289, 289, 229, 0,
+    125, 0, 0, 0, 0, 0, 0, 125, 229, 732, 229, 289, 736, 740, 744, 748, 752, 756, 760, 764, 768, 289, 237, 289,
+    0, 237, 237, 237, 237, 237, 237, 237, 237, 237, 293, 0, 129, 0, 0, 0, 0, 0, 0, 129, 772, 241, 776, 237,
+    241, 241, 241, 241, 241, 241, 241, 241, 241, 237, 245, 237, 0, 245, 245, 245, 245, 245, 245, 245, 245, 245, 241, 0,
+    133, 0, 0, 0, 0, 0, 0, 133, 241, 249, 241, 245, 249, 249, 249, 249, 249, 249, 249, 249, 249, 245, 253, 245,
+    0, 253, 253, 253, 253, 253, 253, 253, 253, 253, 249, 0, 137, 0, 0, 0, 0, 0, 0, 137, 249, 257, 249, 253,
+    257, 257, 257, 257, 257, 257, 257, 257, 257, 253, 261, 253, 0, 261, 261, 261, 261, 261, 261, 261, 261, 261, 257, 0,
+    141, 0, 0, 0, 0, 0, 0, 141, 257, 265, 257, 261, 265, 265, 265, 265, 265, 265, 265, 265, 265, 261, 269, 261,
+    0, 269, 269, 269, 269, 269, 269, 269, 269, 269, 265, 0, 145, 0, 0, 0, 0, 0, 0, 145, 265, 273, 265, 269,
+    273, 273, 273, 273, 273, 273, 273, 273, 273, 269, 233, 269, 0, 233, 233, 233, 233, 233, 233, 233, 233, 233, 273, 0,
+    149, 0, 0, 0, 0, 0, 0, 149, 273, 281, 273, 233, 281, 281, 281, 281, 281, 281, 281, 281, 281, 233, 285, 233,
+    0, 285, 285, 285, 285, 285, 285, 285, 285, 285, 281, 0, 109, 0, 0, 0, 0, 0, 0, 109, 281, 277, 281, 285,
+    277, 277, 277, 277, 277, 277, 277, 277, 277, 285, 56, 285, 0, 60, 64, 68, 72, 76, 80, 84, 88, 92, 277, 0,
+    153, 0, 0, 0, 0, 0, 0, 153, 277, 104, 277, 0, 108, 112, 116, 120, 124, 128, 132, 136, 140, 96, 152, 0,
+    0, 156, 160, 164, 168, 172, 176, 180, 184, 188, 0, 0, 0, 0, 0, 0, 0, 0, 200, 0, 144, 204, 208, 212,
+    216, 220, 224, 228, 232, 236, 0, 252, 0, 192, 256, 260, 264, 268, 272, 276, 280, 284, 288, 0, 0, 0, 0, 0,
+    0, 0, 0, 300, 0, 240, 304, 308, 312, 316, 320, 324, 328, 332, 336, 0, 1, 0, 292, 1, 1, 1, 1, 0,
+    0, 0, 0, 0, 1, 0, 0, 0, 1, 0, 1, 1, 1, 577, 340, 0, 577, 577, 577, 577, 0, 0, 0, 0,
+    0, 577, 0, 0, 0, 577, 0, 577, 577, 577, 0, 0, 0, 0, 0, 392, 0, 1, 396, 400, 404, 408, 412, 416,
+    420, 424, 428, 473, 0, 0, 473, 473, 473, 473, 0, 0, 0, 0, 577, 473, 0, 0, 0, 473, 0, 473, 473, 473,
+    477, 0, 432, 477, 477, 477, 477, 0, 0, 0, 0, 0, 477, 0, 0, 0, 477, 0, 477, 477, 477, 525, 0, 0,
+    525, 525, 525, 525, 473, 0, 0, 0, 0, 525, 0, 0, 0, 525, 0, 525, 525, 525, 573, 0, 0, 573, 573, 573,
+    573, 477, 0, 0, 0, 0, 573, 0, 0, 0, 573, 0, 573, 573, 573, 301, 0, 0, 301, 301, 301, 301, 525, 0,
+    0, 0, 0, 301, 0, 0, 0, 301, 0, 301, 301, 301, 377, 0, 0, 377, 377, 377, 377, 573, 0, 0, 0, 0,
+    377, 0, 0, 0, 377, 0, 377, 377, 377, 0, 0, 0, 0, 0, 680, 0, 301, 684, 688, 692, 696, 700, 704, 708,
+    712, 716, 0, 0, 0, 0, 0, 0, 0, 0, 0, 832, 0, 377, 836, 840, 844, 848, 852, 856, 860, 864, 868, 425,
+    0, 720, 425, 425, 425, 425, 0, 0, 0, 0, 0, 425, 0, 0, 0, 425, 0, 425, 425, 425, 1000, 0, 872, 1004,
+    1008, 1012, 1016, 1020, 1024, 1028, 1032, 1036, 1048, 0, 0, 1052, 1056, 1060, 1064, 1068, 1072, 1076, 1080, 1084, 0, 0, 0, 0,
+    425, 0, 0, 0, 0, 1096, 0, 1040, 1100, 1104, 1108, 1112, 1116, 1120, 1124, 1128, 1132, 1164, 0, 1088, 1168, 1172, 1176, 1180,
+    1184, 1188, 1192, 1196, 1200, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1224, 0, 1136, 1228, 1232, 1236, 1240, 1244, 1248, 1252,
+    1256, 1260, 173, 0, 1204, 173, 173, 173, 173, 0, 0, 0, 0, 0, 173, 0, 0, 0, 173, 0, 173, 173, 173, 0,
+    0, 1264, 749, 749, 749, 0, 749, 749, 0, 0, 749, 0, 0, 0, 749, 749, 753, 753, 753, 0, 753, 753, 0, 0,
+    753, 0, 0, 173, 753, 753, 757, 757, 757, 0, 757, 757, 0, 0, 757, 0, 0, 0, 757, 757, 761, 761, 761, 0,
+    761, 761, 0, 0, 761, 0, 0, 0, 761, 761, 765, 765, 765, 0, 765, 765, 0, 0, 765, 0, 0, 0, 765, 765,
+    769, 769, 769, 0, 769, 769, 0, 0, 769, 0, 0, 0, 769, 769, 773, 773, 773, 0, 773, 773, 0, 0, 773, 0,
+    0, 0, 773, 773, 777, 777, 777, 0, 777, 777, 0, 0, 777, 0, 0, 0, 777, 777, 781, 781, 781, 0, 781, 781,
+    0, 0, 781, 0, 0, 0, 781, 781, 741, 741, 741, 0, 741, 741, 0, 0, 741, 0, 0, 0, 741, 741, 833, 833,
+    968, 0, 833, 833, 737, 628, 833, 0, 737, 737, 833, 833, 737, 0, 837, 837, 737, 737, 837, 837, 789, 789, 837, 0,
+    789, 789, 837, 837, 789, 0, 793, 793, 789, 789, 793, 793, 797, 797, 793, 0, 797, 797, 793, 793, 797, 0, 801, 801,
+    797, 797, 801, 801, 805, 805, 801, 0, 805, 805, 801, 801, 805, 0, 809, 809, 805, 805, 809, 809, 813, 813, 809, 0,
+    813, 813, 809, 809, 813, 0, 817, 817, 813, 813, 817, 817, 821, 821, 817, 0, 821, 821, 817, 817, 821, 0, 825, 825,
+    821, 821, 825, 825, 785, 785, 825, 0, 785, 785, 825, 825, 785, 0, 829, 829, 785, 785, 829, 829, 0, 0, 829, 0,
+    0, 0, 829, 829,
+}
+var gotoBase = []int32 {
+    6, 6, 1, 0, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6,
+    6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 2, 6, 3, 4, 6, 5,
+}
+var gotoCheck = []int32 {
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 0, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, 0, 4, 4, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 3, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 2, -1, 0, -1, -1, 4, -1, -1, -1, 4, 4, -1, -1, -1,
+    5, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 0, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+    -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, 1,
+    0,
+}
+var gotoValue = []int32 {
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 145, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 151, 149, 150, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 158, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 198, 0, 207, 0, 0, 206, 0, 0, 0, 220, 221, 0, 0, 0,
+    233, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 236, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+    0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 321,
+    322,
+}
+var gotoDefault = []int32 { // State for goto entries that are not found in the row of a non-terminal
+    1, 12, 205, 134, 2, 13, 109, 147, 181, 235, 248, 317, 25, 90, 113, 148, 195, 196, 37, 92, 154, 240, 287, 49,
+    61, 74, 86, 273, 261, 285, 219, 243, 302, 135, 163, 168, 245, 304, 136, 241, 290, 137, 138, 139, 140, 141, 142, 143,
+}
+// Non-associative precedence levels that reject chained operations, indexed by state and token type.
+var nonAssociative = map[int]map[int]string {
+    233: { 31: "separator", 32: "separator" },
+}
+
+// Returns the action for a state and token type from the action table, or false if the state has no action for the token.
+func findAction(state int, token int) (actionEntry, bool) {
+    base := actionBase[state]
+    i := int(base) + token + 1
+    if i < 0 || i >= len(actionCheck) || actionCheck[i] != base { return actionEntry { }, false }
+    return actionEntry { int(actionValue[i] & 3), int(actionValue[i] >> 2) }, true
 }
-var parseTable = []tableEntry {
-    { map[int]actionEntry { 3: { 1, 1 }, 26: { 1, 1 }, -1: { 1, 1 }, 4: { 1, 1 }, 5: { 1, 1 }, 2: { 1, 1 } }, map[int]int { 3: 2, 0: 1 } },
-    { map[int]actionEntry { 26: { 2, 0 } }, map[int]int { } },
-    { map[int]actionEntry { 26: { 1, 2 }, 5: { 0, 4 }, -1: { 0, 5 }, 3: { 0, 6 }, 4: { 0, 7 }, 2: { 0, 8 } }, map[int]int { 1: 3 } },
-    { map[int]actionEntry { 3: { 1, 0 }, 4: { 1, 0 }, -1: { 1, 0 }, 5: { 1, 0 }, 2: { 1, 0 }, 26: { 1, 0 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 9 } }, map[int]int { } },
-    { map[int]actionEntry { 18: { 0, 10 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 11 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 12 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 13 } }, map[int]int { } },
-    { map[int]actionEntry { 19: { 0, 14 } }, map[int]int { } },
-    { map[int]actionEntry { 2: { 1, 15 }, 26: { 1, 15 }, -1: { 1, 15 }, 3: { 1, 15 }, 4: { 1, 15 }, 5: { 1, 15 } }, map[int]int { } },
-    { map[int]actionEntry { 19: { 0, 16 }, 18: { 1, 7 } }, map[int]int { 4: 15 } },
-    { map[int]actionEntry { 19: { 0, 18 }, 18: { 1, 12 } }, map[int]int { 6: 17 } },
-    { map[int]actionEntry { 19: { 0, 19 } }, map[int]int { } },
-    { map[int]actionEntry { 25: { 0, 23 }, 8: { 0, 24 }, 14: { 0, 25 }, 24: { 0, 26 }, 23: { 0, 20 }, 20: { 0, 29 } }, map[int]int { 11: 27, 13: 21, 2: 28, 10: 30, 12: 22 } },
-    { map[int]actionEntry { 18: { 0, 31 } }, map[int]int { } },
-    { map[int]actionEntry { 7: { 0, 34 }, 6: { 0, 33 } }, map[int]int { 5: 32 } },
-    { map[int]actionEntry { 18: { 0, 35 } }, map[int]int { } },
-    { map[int]actionEntry { 20: { 0, 29 }, 8: { 0, 24 }, 14: { 0, 25 }, 24: { 0, 26 }, 25: { 0, 23 }, 23: { 0, 20 } }, map[int]int { 10: 30, 12: 22, 11: 27, 13: 21, 2: 36 } },
-    { map[int]actionEntry { 20: { 0, 29 }, 14: { 0, 25 }, 24: { 0, 26 }, 25: { 0, 23 }, 8: { 0, 24 }, 23: { 0, 20 } }, map[int]int { 10: 30, 2: 37, 12: 22, 11: 27, 13: 21 } },
-    { map[int]actionEntry { 14: { 1, 27 }, 16: { 1, 27 }, 23: { 1, 27 }, 24: { 1, 27 }, 20: { 1, 27 }, 15: { 1, 27 }, 22: { 1, 27 }, 18: { 1, 27 }, 21: { 1, 27 }, 12: { 1, 27 }, 8: { 1, 27 }, 10: { 0, 38 }, 11: { 1, 27 }, 25: { 1, 27 }, 13: { 1, 27 } }, map[int]int { } },
-    { map[int]actionEntry { 12: { 0, 39 }, 11: { 0, 40 }, 24: { 1, 35 }, 20: { 1, 35 }, 15: { 1, 35 }, 21: { 1, 35 }, 8: { 1, 35 }, 23: { 1, 35 }, 13: { 0, 42 }, 18: { 1, 35 }, 25: { 1, 35 }, 16: { 1, 35 }, 22: { 1, 35 }, 14: { 1, 35 } }, map[int]int { 9: 41 } },
-    { map[int]actionEntry { 22: { 1, 34 }, 15: { 1, 34 }, 14: { 1, 34 }, 24: { 1, 34 }, 8: { 1, 34 }, 21: { 1, 34 }, 25: { 1, 34 }, 23: { 1, 34 }, 18: { 1, 34 }, 20: { 1, 34 }, 16: { 1, 34 } }, map[int]int { } },
-    { map[int]actionEntry { 13: { 1, 29 }, 11: { 1, 29 }, 15: { 1, 29 }, 18: { 1, 29 }, 14: { 1, 29 }, 16: { 1, 29 }, 12: { 1, 29 }, 21: { 1, 29 }, 20: { 1, 29 }, 8: { 1, 29 }, 22: { 1, 29 }, 24: { 1, 29 }, 25: { 1, 29 }, 23: { 1, 29 } }, map[int]int { } },
-    { map[int]actionEntry { 24: { 1, 30 }, 16: { 1, 30 }, 23: { 1, 30 }, 8: { 1, 30 }, 14: { 1, 30 }, 11: { 1, 30 }, 13: { 1, 30 }, 22: { 1, 30 }, 12: { 1, 30 }, 20: { 1, 30 }, 18: { 1, 30 }, 15: { 1, 30 }, 25: { 1, 30 }, 21: { 1, 30 } }, map[int]int { } },
-    { map[int]actionEntry { 14: { 1, 31 }, 12: { 1, 31 }, 16: { 1, 31 }, 25: { 1, 31 }, 11: { 1, 31 }, 8: { 1, 31 }, 15: { 1, 31 }, 24: { 1, 31 }, 23: { 1, 31 }, 20: { 1, 31 }, 18: { 1, 31 }, 22: { 1, 31 }, 21: { 1, 31 }, 13: { 1, 31 } }, map[int]int { } },
-    { map[int]actionEntry { 20: { 1, 28 }, 18: { 1, 28 }, 24: { 1, 28 }, 15: { 1, 28 }, 25: { 1, 28 }, 23: { 1, 28 }, 12: { 1, 28 }, 16: { 1, 28 }, 21: { 1, 28 }, 11: { 1, 28 }, 14: { 1, 28 }, 8: { 1, 28 }, 13: { 1, 28 }, 22: { 1, 28 } }, map[int]int { } },
-    { map[int]actionEntry { 18: { 1, 33 }, 14: { 0, 25 }, 23: { 0, 20 }, 24: { 0, 26 }, 8: { 0, 24 }, 22: { 1, 33 }, 15: { 1, 33 }, 16: { 1, 33 }, 21: { 1, 33 }, 20: { 0, 29 }, 25: { 0, 23 } }, map[int]int { 13: 21, 12: 43 } },
-    { map[int]actionEntry { 18: { 0, 44 }, 15: { 0, 45 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 20 }, 14: { 0, 25 }, 25: { 0, 23 }, 20: { 0, 29 }, 8: { 0, 24 }, 24: { 0, 26 } }, map[int]int { 2: 46, 11: 27, 10: 30, 12: 22, 13: 21 } },
-    { map[int]actionEntry { 16: { 0, 47 }, 22: { 1, 32 }, 21: { 1, 32 }, 18: { 1, 32 }, 15: { 1, 32 } }, map[int]int { } },
-    { map[int]actionEntry { 3: { 1, 8 }, 5: { 1, 8 }, 4: { 1, 8 }, 26: { 1, 8 }, 2: { 1, 8 }, -1: { 1, 8 } }, map[int]int { } },
-    { map[int]actionEntry { 18: { 1, 6 } }, map[int]int { } },
-    { map[int]actionEntry { 18: { 1, 4 } }, map[int]int { } },
-    { map[int]actionEntry { 18: { 1, 5 } }, map[int]int { } },
-    { map[int]actionEntry { 3: { 1, 13 }, 26: { 1, 13 }, 4: { 1, 13 }, -1: { 1, 13 }, 2: { 1, 13 }, 5: { 1, 13 } }, map[int]int { } },
-    { map[int]actionEntry { 22: { 0, 49 }, 15: { 0, 45 }, 18: { 1, 10 } }, map[int]int { 7: 48 } },
-    { map[int]actionEntry { 18: { 0, 50 }, 15: { 0, 45 } }, map[int]int { } },
-    { map[int]actionEntry { 14: { 0, 25 }, 23: { 0, 20 }, 20: { 0, 29 }, 24: { 0, 26 }, 8: { 0, 24 }, 25: { 0, 23 } }, map[int]int { 12: 51, 13: 21 } },
-    { map[int]actionEntry { 11: { 1, 23 }, 14: { 1, 23 }, 22: { 1, 23 }, 21: { 1, 23 }, 12: { 1, 23 }, 20: { 1, 23 }, 15: { 1, 23 }, 16: { 1, 23 }, 23: { 1, 23 }, 18: { 1, 23 }, 25: { 1, 23 }, 8: { 1, 23 }, 24: { 1, 23 }, 13: { 1, 23 } }, map[int]int { } },
-    { map[int]actionEntry { 8: { 1, 24 }, 24: { 1, 24 }, 22: { 1, 24 }, 21: { 1, 24 }, 11: { 1, 24 }, 14: { 1, 24 }, 25: { 1, 24 }, 18: { 1, 24 }, 23: { 1, 24 }, 12: { 1, 24 }, 20: { 1, 24 }, 13: { 1, 24 }, 16: { 1, 24 }, 15: { 1, 24 } }, map[int]int { } },
-    { map[int]actionEntry { 11: { 1, 25 }, 14: { 1, 25 }, 25: { 1, 25 }, 24: { 1, 25 }, 16: { 1, 25 }, 18: { 1, 25 }, 20: { 1, 25 }, 21: { 1, 25 }, 23: { 1, 25 }, 8: { 1, 25 }, 15: { 1, 25 }, 12: { 1, 25 }, 22: { 1, 25 }, 13: { 1, 25 } }, map[int]int { } },
-    { map[int]actionEntry { 11: { 1, 22 }, 25: { 1, 22 }, 18: { 1, 22 }, 22: { 1, 22 }, 24: { 1, 22 }, 12: { 1, 22 }, 8: { 1, 22 }, 20: { 1, 22 }, 16: { 1, 22 }, 23: { 1, 22 }, 14: { 1, 22 }, 21: { 1, 22 }, 13: { 1, 22 }, 15: { 1, 22 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 1, 20 }, 24: { 1, 20 }, 16: { 1, 20 }, 21: { 1, 20 }, 18: { 1, 20 }, 20: { 1, 20 }, 14: { 1, 20 }, 25: { 1, 20 }, 15: { 1, 20 }, 8: { 1, 20 }, 22: { 1, 20 } }, map[int]int { } },
-    { map[int]actionEntry { 4: { 1, 14 }, 26: { 1, 14 }, 2: { 1, 14 }, 3: { 1, 14 }, -1: { 1, 14 }, 5: { 1, 14 } }, map[int]int { } },
-    { map[int]actionEntry { 25: { 0, 23 }, 8: { 0, 24 }, 20: { 0, 29 }, 23: { 0, 20 }, 24: { 0, 26 }, 14: { 0, 25 } }, map[int]int { 13: 21, 11: 27, 10: 52, 12: 22 } },
-    { map[int]actionEntry { 21: { 0, 53 }, 15: { 0, 45 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 54 } }, map[int]int { } },
-    { map[int]actionEntry { 18: { 1, 11 } }, map[int]int { } },
-    { map[int]actionEntry { 9: { 0, 55 } }, map[int]int { } },
-    { map[int]actionEntry { 4: { 1, 3 }, 3: { 1, 3 }, 5: { 1, 3 }, -1: { 1, 3 }, 26: { 1, 3 }, 2: { 1, 3 } }, map[int]int { } },
-    { map[int]actionEntry { 20: { 1, 21 }, 15: { 1, 21 }, 8: { 1, 21 }, 18: { 1, 21 }, 16: { 1, 21 }, 24: { 1, 21 }, 21: { 1, 21 }, 25: { 1, 21 }, 22: { 1, 21 }, 23: { 1, 21 }, 14: { 1, 21 } }, map[int]int { } },
-    { map[int]actionEntry { 16: { 0, 47 }, 18: { 1, 16 }, 22: { 1, 16 }, 21: { 1, 16 }, 15: { 1, 16 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 1, 26 }, 8: { 1, 26 }, 20: { 1, 26 }, 12: { 1, 26 }, 14: { 1, 26 }, 22: { 1, 26 }, 24: { 1, 26 }, 25: { 1, 26 }, 18: { 1, 26 }, 16: { 1, 26 }, 13: { 1, 26 }, 21: { 1, 26 }, 11: { 1, 26 }, 15: { 1, 26 } }, map[int]int { } },
-    { map[int]actionEntry { 17: { 0, 56 }, 16: { 1, 18 }, 15: { 1, 18 }, 18: { 1, 18 }, 22: { 1, 18 }, 21: { 1, 18 } }, map[int]int { 8: 57 } },
-    { map[int]actionEntry { 18: { 1, 9 } }, map[int]int { } },
-    { map[int]actionEntry { 23: { 0, 58 } }, map[int]int { } },
-    { map[int]actionEntry { 15: { 1, 19 }, 18: { 1, 19 }, 22: { 1, 19 }, 21: { 1, 19 }, 16: { 1, 19 } }, map[int]int { } },
-    { map[int]actionEntry { 22: { 1, 17 }, 21: { 1, 17 }, 18: { 1, 17 }, 15: { 1, 17 }, 16: { 1, 17 } }, map[int]int { } },
+// Returns the next state for a state and non-terminal from the goto table.
+func findGoto(state int, left int) int {
+    base := gotoBase[left]
+    i := int(base) + state
+    if i < 0 || i >= len(gotoCheck) || gotoCheck[i] != base { return int(gotoDefault[left]) }
+    return int(gotoValue[i])
+}
+// Returns the message of a syntax error caused by a token in a state.
+func describeError(state int, token Token) string {
+    if level, ok := nonAssociative[state][int(token.Type)]; ok {
+        return fmt.Sprintf("Unexpected token %q, operations of non-associative precedence level %q cannot be chained", token.Value, level)
+    }
+    return fmt.Sprintf("Unexpected token %q", token.Value)
 }
 
 // Parser struct. Converts token stream to parse tree.
@@ -141,35 +671,60 @@ type Parser struct {
 
 // Base visitor interface. Describes functions necessary to implement to traverse parse tree.
 type BaseVisitor[T any] interface {
-    VisitGrammar(node *ParseTreeNode) T
-    VisitRuleStmt(node *ParseTreeNode) T
-    VisitPrecedenceStmt(node *ParseTreeNode) T
-    VisitTokenStmt(node *ParseTreeNode) T
-    VisitFragmentStmt(node *ParseTreeNode) T
-    VisitStmt(node *ParseTreeNode) T
-    VisitUnionExpr(node *ParseTreeNode) T
-    VisitLabelExpr(node *ParseTreeNode) T
-    VisitConcatExpr(node *ParseTreeNode) T
-    VisitAliasExpr(node *ParseTreeNode) T
-    VisitQuantifierExpr(node *ParseTreeNode) T
-    VisitGroupExpr(node *ParseTreeNode) T
-    VisitIdentifierExpr(node *ParseTreeNode) T
-    VisitStringExpr(node *ParseTreeNode) T
-    VisitClassExpr(node *ParseTreeNode) T
-    VisitErrorExpr(node *ParseTreeNode) T
-    VisitAnyExpr(node *ParseTreeNode) T
+    VisitGrammar(node GrammarNode) T
+    VisitRuleStmt(node RuleStmtNode) T
+    VisitPrecedenceStmt(node PrecedenceStmtNode) T
+    VisitTokenStmt(node TokenStmtNode) T
+    VisitFragmentStmt(node FragmentStmtNode) T
+    VisitModeStmt(node ModeStmtNode) T
+    VisitImportStmt(node ImportStmtNode) T
+    VisitStartStmt(node StartStmtNode) T
+    VisitOptionStmt(node OptionStmtNode) T
+    VisitStmt(node StmtNode) T
+    VisitSkipAction(node SkipActionNode) T
+    VisitPushModeAction(node PushModeActionNode) T
+    VisitPopModeAction(node PopModeActionNode) T
+    VisitModeAction(node ModeActionNode) T
+    VisitNocaseAction(node NocaseActionNode) T
+    VisitChannelAction(node ChannelActionNode) T
+    VisitUnionExpr(node UnionExprNode) T
+    VisitLabelExpr(node LabelExprNode) T
+    VisitConcatExpr(node ConcatExprNode) T
+    VisitDifferenceExpr(node DifferenceExprNode) T
+    VisitIntersectionExpr(node IntersectionExprNode) T
+    VisitAliasExpr(node AliasExprNode) T
+    VisitDropExpr(node DropExprNode) T
+    VisitHoistExpr(node HoistExprNode) T
+    VisitSeparatedExpr(node SeparatedExprNode) T
+    VisitQuantifierExpr(node QuantifierExprNode) T
+    VisitRepeatExpr(node RepeatExprNode) T
+    VisitGroupExpr(node GroupExprNode) T
+    VisitTemplateExpr(node TemplateExprNode) T
+    VisitIdentifierExpr(node IdentifierExprNode) T
+    VisitStringExpr(node StringExprNode) T
+    VisitNocaseStringExpr(node NocaseStringExprNode) T
+    VisitClassExpr(node ClassExprNode) T
+    VisitErrorExpr(node ErrorExprNode) T
+    VisitAnyExpr(node AnyExprNode) T
 }
 
-// Function called when the parser encounters an error.
-type ParserErrorHandler func (token Token)
-var DEFAULT_PARSER_HANDLER = func (token Token) {
-    fmt.Fprintf(os.Stderr, "Syntax error: Unexpected token %q - %d:%d\n", token.Value, token.Start.Line, token.Start.Col)
+// Ambiguity visitor interface. Implemented by visitors that choose between the alternatives of ambiguity nodes.
+type AmbiguityVisitor[T any] interface { VisitAmbiguity(node *AmbiguityNode) T }
+
+// Function called when the parser encounters an error, given the token that caused it and a description of the error.
+type ParserErrorHandler func (token Token, message string)
+var DEFAULT_PARSER_HANDLER = func (token Token, message string) {
+    fmt.Fprintf(os.Stderr, "Syntax error: %s - %d:%d\n", message, token.Start.Line, token.Start.Col)
 }
 
 // Returns new parser struct.
 func NewParser(lexer BaseLexer, handler ParserErrorHandler) *Parser { return &Parser { lexer, handler } }
 // Generates parse tree based on token stream from lexer.
-func (p *Parser) Parse() *ParseTreeNode {
+func (p *Parser) Parse() *ParseTreeNode { return p.parse(0) }
+
+
+// Generates parse tree starting from the given start state.
+func (p *Parser) parse(initial int) *ParseTreeNode {
     // Stack state struct. Holds the state identifier and the corresponding parse tree node.
     type StackState struct {
         state int
@@ -179,24 +734,24 @@ func (p *Parser) Parse() *ParseTreeNode {
     const (NORMAL int = iota; AUXILIARY; FLATTEN; REMOVED)
     const (SHIFT int = iota; REDUCE; ACCEPT)
     // Initialize current token and stack
-    token, stack := p.lexer.Next(), []StackState { { 0, nil } }
+    token, stack := p.lexer.Next(), []StackState { { initial, nil } }
     main: for {
         // Get the current state at the top of the stack and find the action to take
         // Next action is determined by action table given state index and the current token type
         state := stack[len(stack) - 1].state
-        action, ok := parseTable[state].actions[int(token.Type)]
+        action, ok := findAction(state, int(token.Type))
         if !ok {
             // If the table does not have a valid action, cannot parse current token
-            p.handler(token)
+            p.handler(token, describeError(state, token))
             for {
                 // Pop states off the stack until a valid shift action on the error terminal is found
-                if action, ok := parseTable[state].actions[-1]; ok && action.actionType == SHIFT {
+                if action, ok := findAction(state, -1); ok && action.actionType == SHIFT {
                     // Shift token that caused error onto stack
                     // Then enter panic mode and read tokens until a valid action can be made
                     stack = append(stack, StackState { action.value, token })
                     for {
                         token = p.lexer.Next()
-                        if _, ok := parseTable[action.value].actions[int(token.Type)]; ok { continue main }
+                        if _, ok := findAction(action.value, int(token.Type)); ok { continue main }
                         if token.Type == EOF { return nil }
                     }
                 }
@@ -221,14 +776,23 @@ func (p *Parser) Parse() *ParseTreeNode {
                 // Collect child nodes from current states on the stack and create node for reduction
                 children := make([]ParseTreeChild, production.length)
                 for i, s := range stack[i:] { children[i] = s.node }
-                // Find start and end locations
+                // A hoisted child is passed through in place of the node
+                if production.hoist >= 0 { node = children[production.hoist]; break }
+                // Find start and end locations (including dropped children), then remove dropped children
                 start, end := findLocationRange(children)
+                if production.dropped != nil {
+                    kept := make([]ParseTreeChild, 0, len(children))
+                    for i, c := range children {
+                        if !production.dropped[i] { kept = append(kept, c) }
+                    }
+                    children = kept
+                }
                 node = &ParseTreeNode { children, start, end, production }
             case FLATTEN:
                 // Handle flatten productions
-                // Of the two nodes popped, preserve the first and add the second as a child of the first
+                // Of the nodes popped, preserve the first and add the last as a child of the first (separators are dropped)
                 // Results in quantified expressions in the grammar generating arrays of elements
-                list, element := stack[i].node.(*ParseTreeNode), stack[i + 1].node
+                list, element := stack[i].node.(*ParseTreeNode), stack[len(stack) - 1].node
                 list.Children = append(list.Children, element)
                 switch n := element.(type) {
                 case *ParseTreeNode: list.End = n.End
@@ -242,7 +806,7 @@ func (p *Parser) Parse() *ParseTreeNode {
             // Given new state at the top of the stack, find next state based on the goto table
             stack = stack[:i]
             state := stack[i - 1].state
-            next := parseTable[state].gotos[production.left]
+            next := findGoto(state, production.left)
             // Add new state to top of the stack
             stack = append(stack, StackState { next, node })
         // Return non-terminal in auxiliary start production on accept
@@ -259,6 +823,7 @@ func findLocationRange(children []ParseTreeChild) (Location, Location) {
         switch n := c.(type) {
         case nil: continue
         case *ParseTreeNode: start = n.Start
+        case *AmbiguityNode: start = n.Start
         case Token:          start = n.Start
         }
         break
@@ -269,6 +834,7 @@ func findLocationRange(children []ParseTreeChild) (Location, Location) {
         switch n := c.(type) {
         case nil: continue
         case *ParseTreeNode: end = n.End
+        case *AmbiguityNode: end = n.End
         case Token:          end = n.End
         }
         break
@@ -277,49 +843,636 @@ func findLocationRange(children []ParseTreeChild) (Location, Location) {
 }
 
 // Given a parse tree node, dispatches the corresponding function in the visitor.
+// Typed nodes are unwrapped to their underlying parse tree node.
+// Ambiguity nodes are passed to the visitor's VisitAmbiguity function, or its first alternative is visited if it is not implemented.
 func VisitNode[T any](visitor BaseVisitor[T], node ParseTreeChild) T {
-    if n, ok := node.(*ParseTreeNode); ok {
-        switch n.data.visitor {
-        case "grammar": return visitor.VisitGrammar(n)
-        case "ruleStmt": return visitor.VisitRuleStmt(n)
-        case "precedenceStmt": return visitor.VisitPrecedenceStmt(n)
-        case "tokenStmt": return visitor.VisitTokenStmt(n)
-        case "fragmentStmt": return visitor.VisitFragmentStmt(n)
-        case "stmt": return visitor.VisitStmt(n)
-        case "unionExpr": return visitor.VisitUnionExpr(n)
-        case "labelExpr": return visitor.VisitLabelExpr(n)
-        case "concatExpr": return visitor.VisitConcatExpr(n)
-        case "aliasExpr": return visitor.VisitAliasExpr(n)
-        case "quantifierExpr": return visitor.VisitQuantifierExpr(n)
-        case "groupExpr": return visitor.VisitGroupExpr(n)
-        case "identifierExpr": return visitor.VisitIdentifierExpr(n)
-        case "stringExpr": return visitor.VisitStringExpr(n)
-        case "classExpr": return visitor.VisitClassExpr(n)
-        case "errorExpr": return visitor.VisitErrorExpr(n)
-        case "anyExpr": return visitor.VisitAnyExpr(n)
+    if a, ok := node.(*AmbiguityNode); ok {
+        if v, ok := visitor.(AmbiguityVisitor[T]); ok { return v.VisitAmbiguity(a) }
+        return VisitNode[T](visitor, a.Alternatives[0])
+    }
+    if w, ok := node.(interface { ParseTree() *ParseTreeNode }); ok {
+        if n := w.ParseTree(); n != nil {
+            switch n.data.visitor {
+            case "grammar": return visitor.VisitGrammar(GrammarNode { n })
+            case "ruleStmt": return visitor.VisitRuleStmt(RuleStmtNode { n })
+            case "precedenceStmt": return visitor.VisitPrecedenceStmt(PrecedenceStmtNode { n })
+            case "tokenStmt": return visitor.VisitTokenStmt(TokenStmtNode { n })
+            case "fragmentStmt": return visitor.VisitFragmentStmt(FragmentStmtNode { n })
+            case "modeStmt": return visitor.VisitModeStmt(ModeStmtNode { n })
+            case "importStmt": return visitor.VisitImportStmt(ImportStmtNode { n })
+            case "startStmt": return visitor.VisitStartStmt(StartStmtNode { n })
+            case "optionStmt": return visitor.VisitOptionStmt(OptionStmtNode { n })
+            case "stmt": return visitor.VisitStmt(stmtNode { n })
+            case "skipAction": return visitor.VisitSkipAction(SkipActionNode { n })
+            case "pushModeAction": return visitor.VisitPushModeAction(PushModeActionNode { n })
+            case "popModeAction": return visitor.VisitPopModeAction(PopModeActionNode { n })
+            case "modeAction": return visitor.VisitModeAction(ModeActionNode { n })
+            case "nocaseAction": return visitor.VisitNocaseAction(NocaseActionNode { n })
+            case "channelAction": return visitor.VisitChannelAction(ChannelActionNode { n })
+            case "unionExpr": return visitor.VisitUnionExpr(UnionExprNode { n })
+            case "labelExpr": return visitor.VisitLabelExpr(LabelExprNode { n })
+            case "concatExpr": return visitor.VisitConcatExpr(ConcatExprNode { n })
+            case "differenceExpr": return visitor.VisitDifferenceExpr(DifferenceExprNode { n })
+            case "intersectionExpr": return visitor.VisitIntersectionExpr(IntersectionExprNode { n })
+            case "aliasExpr": return visitor.VisitAliasExpr(AliasExprNode { n })
+            case "dropExpr": return visitor.VisitDropExpr(DropExprNode { n })
+            case "hoistExpr": return visitor.VisitHoistExpr(HoistExprNode { n })
+            case "separatedExpr": return visitor.VisitSeparatedExpr(SeparatedExprNode { n })
+            case "quantifierExpr": return visitor.VisitQuantifierExpr(QuantifierExprNode { n })
+            case "repeatExpr": return visitor.VisitRepeatExpr(RepeatExprNode { n })
+            case "groupExpr": return visitor.VisitGroupExpr(GroupExprNode { n })
+            case "templateExpr": return visitor.VisitTemplateExpr(TemplateExprNode { n })
+            case "identifierExpr": return visitor.VisitIdentifierExpr(IdentifierExprNode { n })
+            case "stringExpr": return visitor.VisitStringExpr(StringExprNode { n })
+            case "nocaseStringExpr": return visitor.VisitNocaseStringExpr(NocaseStringExprNode { n })
+            case "classExpr": return visitor.VisitClassExpr(ClassExprNode { n })
+            case "errorExpr": return visitor.VisitErrorExpr(ErrorExprNode { n })
+            case "anyExpr": return visitor.VisitAnyExpr(AnyExprNode { n })
+            }
         }
     }
     panic("Invalid parse tree child passed to VisitNode()")
 }
 
+// Default visitor struct. Implements every visitor function by visiting the children of the node, may be embedded to only implement some functions.
+// Children are visited using Self, which should be set to the embedding visitor so that its functions are called instead.
+// Results of labeled children are combined using Aggregate, if nil, the result of the last labeled child is returned.
+type DefaultVisitor[T any] struct {
+    Self      BaseVisitor[T]
+    Aggregate func (result, next T) T
+}
+func (v DefaultVisitor[T]) VisitGrammar(node GrammarNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitRuleStmt(node RuleStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitPrecedenceStmt(node PrecedenceStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitTokenStmt(node TokenStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitFragmentStmt(node FragmentStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitModeStmt(node ModeStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitImportStmt(node ImportStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitStartStmt(node StartStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitOptionStmt(node OptionStmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitStmt(node StmtNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitSkipAction(node SkipActionNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitPushModeAction(node PushModeActionNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitPopModeAction(node PopModeActionNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitModeAction(node ModeActionNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitNocaseAction(node NocaseActionNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitChannelAction(node ChannelActionNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitUnionExpr(node UnionExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitLabelExpr(node LabelExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitConcatExpr(node ConcatExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitDifferenceExpr(node DifferenceExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitIntersectionExpr(node IntersectionExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitAliasExpr(node AliasExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitDropExpr(node DropExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitHoistExpr(node HoistExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitSeparatedExpr(node SeparatedExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitQuantifierExpr(node QuantifierExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitRepeatExpr(node RepeatExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitGroupExpr(node GroupExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitTemplateExpr(node TemplateExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitIdentifierExpr(node IdentifierExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitStringExpr(node StringExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitNocaseStringExpr(node NocaseStringExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitClassExpr(node ClassExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitErrorExpr(node ErrorExprNode) T { return v.VisitChildren(node.ParseTree()) }
+func (v DefaultVisitor[T]) VisitAnyExpr(node AnyExprNode) T { return v.VisitChildren(node.ParseTree()) }
+
+// Visits each child of the node, unlabeled children (such as lists) are traversed until a labeled node is found.
+// Returns the combined result of each labeled node, or the zero value if there are none.
+func (v DefaultVisitor[T]) VisitChildren(node *ParseTreeNode) T {
+    var result T
+    if node == nil { return result }
+    var self BaseVisitor[T] = v
+    if v.Self != nil { self = v.Self }
+    for _, c := range node.Children {
+        var next T
+        switch n := c.(type) {
+        case *ParseTreeNode:
+            if n == nil { continue }
+            if n.data.visitor == "" { next = v.VisitChildren(n) } else { next = VisitNode[T](self, n) }
+        case *AmbiguityNode: next = VisitNode[T](self, n)
+        default: continue
+        }
+        if v.Aggregate != nil { result = v.Aggregate(result, next) } else { result = next }
+    }
+    return result
+}
+
+// Listener interface. Describes functions called when entering and exiting each node while walking the parse tree.
+type Listener interface {
+    EnterGrammar(node GrammarNode)
+    ExitGrammar(node GrammarNode)
+    EnterRuleStmt(node RuleStmtNode)
+    ExitRuleStmt(node RuleStmtNode)
+    EnterPrecedenceStmt(node PrecedenceStmtNode)
+    ExitPrecedenceStmt(node PrecedenceStmtNode)
+    EnterTokenStmt(node TokenStmtNode)
+    ExitTokenStmt(node TokenStmtNode)
+    EnterFragmentStmt(node FragmentStmtNode)
+    ExitFragmentStmt(node FragmentStmtNode)
+    EnterModeStmt(node ModeStmtNode)
+    ExitModeStmt(node ModeStmtNode)
+    EnterImportStmt(node ImportStmtNode)
+    ExitImportStmt(node ImportStmtNode)
+    EnterStartStmt(node StartStmtNode)
+    ExitStartStmt(node StartStmtNode)
+    EnterOptionStmt(node OptionStmtNode)
+    ExitOptionStmt(node OptionStmtNode)
+    EnterStmt(node StmtNode)
+    ExitStmt(node StmtNode)
+    EnterSkipAction(node SkipActionNode)
+    ExitSkipAction(node SkipActionNode)
+    EnterPushModeAction(node PushModeActionNode)
+    ExitPushModeAction(node PushModeActionNode)
+    EnterPopModeAction(node PopModeActionNode)
+    ExitPopModeAction(node PopModeActionNode)
+    EnterModeAction(node ModeActionNode)
+    ExitModeAction(node ModeActionNode)
+    EnterNocaseAction(node NocaseActionNode)
+    ExitNocaseAction(node NocaseActionNode)
+    EnterChannelAction(node ChannelActionNode)
+    ExitChannelAction(node ChannelActionNode)
+    EnterUnionExpr(node UnionExprNode)
+    ExitUnionExpr(node UnionExprNode)
+    EnterLabelExpr(node LabelExprNode)
+    ExitLabelExpr(node LabelExprNode)
+    EnterConcatExpr(node ConcatExprNode)
+    ExitConcatExpr(node ConcatExprNode)
+    EnterDifferenceExpr(node DifferenceExprNode)
+    ExitDifferenceExpr(node DifferenceExprNode)
+    EnterIntersectionExpr(node IntersectionExprNode)
+    ExitIntersectionExpr(node IntersectionExprNode)
+    EnterAliasExpr(node AliasExprNode)
+    ExitAliasExpr(node AliasExprNode)
+    EnterDropExpr(node DropExprNode)
+    ExitDropExpr(node DropExprNode)
+    EnterHoistExpr(node HoistExprNode)
+    ExitHoistExpr(node HoistExprNode)
+    EnterSeparatedExpr(node SeparatedExprNode)
+    ExitSeparatedExpr(node SeparatedExprNode)
+    EnterQuantifierExpr(node QuantifierExprNode)
+    ExitQuantifierExpr(node QuantifierExprNode)
+    EnterRepeatExpr(node RepeatExprNode)
+    ExitRepeatExpr(node RepeatExprNode)
+    EnterGroupExpr(node GroupExprNode)
+    ExitGroupExpr(node GroupExprNode)
+    EnterTemplateExpr(node TemplateExprNode)
+    ExitTemplateExpr(node TemplateExprNode)
+    EnterIdentifierExpr(node IdentifierExprNode)
+    ExitIdentifierExpr(node IdentifierExprNode)
+    EnterStringExpr(node StringExprNode)
+    ExitStringExpr(node StringExprNode)
+    EnterNocaseStringExpr(node NocaseStringExprNode)
+    ExitNocaseStringExpr(node NocaseStringExprNode)
+    EnterClassExpr(node ClassExprNode)
+    ExitClassExpr(node ClassExprNode)
+    EnterErrorExpr(node ErrorExprNode)
+    ExitErrorExpr(node ErrorExprNode)
+    EnterAnyExpr(node AnyExprNode)
+    ExitAnyExpr(node AnyExprNode)
+}
+
+// Base listener struct. Implements every listener function without any effect, may be embedded to only implement some functions.
+type BaseListener struct { }
+func (BaseListener) EnterGrammar(node GrammarNode) { }
+func (BaseListener) ExitGrammar(node GrammarNode) { }
+func (BaseListener) EnterRuleStmt(node RuleStmtNode) { }
+func (BaseListener) ExitRuleStmt(node RuleStmtNode) { }
+func (BaseListener) EnterPrecedenceStmt(node PrecedenceStmtNode) { }
+func (BaseListener) ExitPrecedenceStmt(node PrecedenceStmtNode) { }
+func (BaseListener) EnterTokenStmt(node TokenStmtNode) { }
+func (BaseListener) ExitTokenStmt(node TokenStmtNode) { }
+func (BaseListener) EnterFragmentStmt(node FragmentStmtNode) { }
+func (BaseListener) ExitFragmentStmt(node FragmentStmtNode) { }
+func (BaseListener) EnterModeStmt(node ModeStmtNode) { }
+func (BaseListener) ExitModeStmt(node ModeStmtNode) { }
+func (BaseListener) EnterImportStmt(node ImportStmtNode) { }
+func (BaseListener) ExitImportStmt(node ImportStmtNode) { }
+func (BaseListener) EnterStartStmt(node StartStmtNode) { }
+func (BaseListener) ExitStartStmt(node StartStmtNode) { }
+func (BaseListener) EnterOptionStmt(node OptionStmtNode) { }
+func (BaseListener) ExitOptionStmt(node OptionStmtNode) { }
+func (BaseListener) EnterStmt(node StmtNode) { }
+func (BaseListener) ExitStmt(node StmtNode) { }
+func (BaseListener) EnterSkipAction(node SkipActionNode) { }
+func (BaseListener) ExitSkipAction(node SkipActionNode) { }
+func (BaseListener) EnterPushModeAction(node PushModeActionNode) { }
+func (BaseListener) ExitPushModeAction(node PushModeActionNode) { }
+func (BaseListener) EnterPopModeAction(node PopModeActionNode) { }
+func (BaseListener) ExitPopModeAction(node PopModeActionNode) { }
+func (BaseListener) EnterModeAction(node ModeActionNode) { }
+func (BaseListener) ExitModeAction(node ModeActionNode) { }
+func (BaseListener) EnterNocaseAction(node NocaseActionNode) { }
+func (BaseListener) ExitNocaseAction(node NocaseActionNode) { }
+func (BaseListener) EnterChannelAction(node ChannelActionNode) { }
+func (BaseListener) ExitChannelAction(node ChannelActionNode) { }
+func (BaseListener) EnterUnionExpr(node UnionExprNode) { }
+func (BaseListener) ExitUnionExpr(node UnionExprNode) { }
+func (BaseListener) EnterLabelExpr(node LabelExprNode) { }
+func (BaseListener) ExitLabelExpr(node LabelExprNode) { }
+func (BaseListener) EnterConcatExpr(node ConcatExprNode) { }
+func (BaseListener) ExitConcatExpr(node ConcatExprNode) { }
+func (BaseListener) EnterDifferenceExpr(node DifferenceExprNode) { }
+func (BaseListener) ExitDifferenceExpr(node DifferenceExprNode) { }
+func (BaseListener) EnterIntersectionExpr(node IntersectionExprNode) { }
+func (BaseListener) ExitIntersectionExpr(node IntersectionExprNode) { }
+func (BaseListener) EnterAliasExpr(node AliasExprNode) { }
+func (BaseListener) ExitAliasExpr(node AliasExprNode) { }
+func (BaseListener) EnterDropExpr(node DropExprNode) { }
+func (BaseListener) ExitDropExpr(node DropExprNode) { }
+func (BaseListener) EnterHoistExpr(node HoistExprNode) { }
+func (BaseListener) ExitHoistExpr(node HoistExprNode) { }
+func (BaseListener) EnterSeparatedExpr(node SeparatedExprNode) { }
+func (BaseListener) ExitSeparatedExpr(node SeparatedExprNode) { }
+func (BaseListener) EnterQuantifierExpr(node QuantifierExprNode) { }
+func (BaseListener) ExitQuantifierExpr(node QuantifierExprNode) { }
+func (BaseListener) EnterRepeatExpr(node RepeatExprNode) { }
+func (BaseListener) ExitRepeatExpr(node RepeatExprNode) { }
+func (BaseListener) EnterGroupExpr(node GroupExprNode) { }
+func (BaseListener) ExitGroupExpr(node GroupExprNode) { }
+func (BaseListener) EnterTemplateExpr(node TemplateExprNode) { }
+func (BaseListener) ExitTemplateExpr(node TemplateExprNode) { }
+func (BaseListener) EnterIdentifierExpr(node IdentifierExprNode) { }
+func (BaseListener) ExitIdentifierExpr(node IdentifierExprNode) { }
+func (BaseListener) EnterStringExpr(node StringExprNode) { }
+func (BaseListener) ExitStringExpr(node StringExprNode) { }
+func (BaseListener) EnterNocaseStringExpr(node NocaseStringExprNode) { }
+func (BaseListener) ExitNocaseStringExpr(node NocaseStringExprNode) { }
+func (BaseListener) EnterClassExpr(node ClassExprNode) { }
+func (BaseListener) ExitClassExpr(node ClassExprNode) { }
+func (BaseListener) EnterErrorExpr(node ErrorExprNode) { }
+func (BaseListener) ExitErrorExpr(node ErrorExprNode) { }
+func (BaseListener) EnterAnyExpr(node AnyExprNode) { }
+func (BaseListener) ExitAnyExpr(node AnyExprNode) { }
+
+// Walks the parse tree depth-first, calling the listener functions for each node and its children in order.
+// Only the first alternative of ambiguity nodes is walked.
+func Walk(listener Listener, tree ParseTreeChild) {
+    if a, ok := tree.(*AmbiguityNode); ok { Walk(listener, a.Alternatives[0]); return }
+    w, ok := tree.(interface { ParseTree() *ParseTreeNode })
+    if !ok { return }
+    n := w.ParseTree()
+    if n == nil { return }
+    switch n.data.visitor {
+        case "grammar": listener.EnterGrammar(GrammarNode { n })
+        case "ruleStmt": listener.EnterRuleStmt(RuleStmtNode { n })
+        case "precedenceStmt": listener.EnterPrecedenceStmt(PrecedenceStmtNode { n })
+        case "tokenStmt": listener.EnterTokenStmt(TokenStmtNode { n })
+        case "fragmentStmt": listener.EnterFragmentStmt(FragmentStmtNode { n })
+        case "modeStmt": listener.EnterModeStmt(ModeStmtNode { n })
+        case "importStmt": listener.EnterImportStmt(ImportStmtNode { n })
+        case "startStmt": listener.EnterStartStmt(StartStmtNode { n })
+        case "optionStmt": listener.EnterOptionStmt(OptionStmtNode { n })
+        case "stmt": listener.EnterStmt(stmtNode { n })
+        case "skipAction": listener.EnterSkipAction(SkipActionNode { n })
+        case "pushModeAction": listener.EnterPushModeAction(PushModeActionNode { n })
+        case "popModeAction": listener.EnterPopModeAction(PopModeActionNode { n })
+        case "modeAction": listener.EnterModeAction(ModeActionNode { n })
+        case "nocaseAction": listener.EnterNocaseAction(NocaseActionNode { n })
+        case "channelAction": listener.EnterChannelAction(ChannelActionNode { n })
+        case "unionExpr": listener.EnterUnionExpr(UnionExprNode { n })
+        case "labelExpr": listener.EnterLabelExpr(LabelExprNode { n })
+        case "concatExpr": listener.EnterConcatExpr(ConcatExprNode { n })
+        case "differenceExpr": listener.EnterDifferenceExpr(DifferenceExprNode { n })
+        case "intersectionExpr": listener.EnterIntersectionExpr(IntersectionExprNode { n })
+        case "aliasExpr": listener.EnterAliasExpr(AliasExprNode { n })
+        case "dropExpr": listener.EnterDropExpr(DropExprNode { n })
+        case "hoistExpr": listener.EnterHoistExpr(HoistExprNode { n })
+        case "separatedExpr": listener.EnterSeparatedExpr(SeparatedExprNode { n })
+        case "quantifierExpr": listener.EnterQuantifierExpr(QuantifierExprNode { n })
+        case "repeatExpr": listener.EnterRepeatExpr(RepeatExprNode { n })
+        case "groupExpr": listener.EnterGroupExpr(GroupExprNode { n })
+        case "templateExpr": listener.EnterTemplateExpr(TemplateExprNode { n })
+        case "identifierExpr": listener.EnterIdentifierExpr(IdentifierExprNode { n })
+        case "stringExpr": listener.EnterStringExpr(StringExprNode { n })
+        case "nocaseStringExpr": listener.EnterNocaseStringExpr(NocaseStringExprNode { n })
+        case "classExpr": listener.EnterClassExpr(ClassExprNode { n })
+        case "errorExpr": listener.EnterErrorExpr(ErrorExprNode { n })
+        case "anyExpr": listener.EnterAnyExpr(AnyExprNode { n })
+    }
+    for _, c := range n.Children { Walk(listener, c) }
+    switch n.data.visitor {
+        case "grammar": listener.ExitGrammar(GrammarNode { n })
+        case "ruleStmt": listener.ExitRuleStmt(RuleStmtNode { n })
+        case "precedenceStmt": listener.ExitPrecedenceStmt(PrecedenceStmtNode { n })
+        case "tokenStmt": listener.ExitTokenStmt(TokenStmtNode { n })
+        case "fragmentStmt": listener.ExitFragmentStmt(FragmentStmtNode { n })
+        case "modeStmt": listener.ExitModeStmt(ModeStmtNode { n })
+        case "importStmt": listener.ExitImportStmt(ImportStmtNode { n })
+        case "startStmt": listener.ExitStartStmt(StartStmtNode { n })
+        case "optionStmt": listener.ExitOptionStmt(OptionStmtNode { n })
+        case "stmt": listener.ExitStmt(stmtNode { n })
+        case "skipAction": listener.ExitSkipAction(SkipActionNode { n })
+        case "pushModeAction": listener.ExitPushModeAction(PushModeActionNode { n })
+        case "popModeAction": listener.ExitPopModeAction(PopModeActionNode { n })
+        case "modeAction": listener.ExitModeAction(ModeActionNode { n })
+        case "nocaseAction": listener.ExitNocaseAction(NocaseActionNode { n })
+        case "channelAction": listener.ExitChannelAction(ChannelActionNode { n })
+        case "unionExpr": listener.ExitUnionExpr(UnionExprNode { n })
+        case "labelExpr": listener.ExitLabelExpr(LabelExprNode { n })
+        case "concatExpr": listener.ExitConcatExpr(ConcatExprNode { n })
+        case "differenceExpr": listener.ExitDifferenceExpr(DifferenceExprNode { n })
+        case "intersectionExpr": listener.ExitIntersectionExpr(IntersectionExprNode { n })
+        case "aliasExpr": listener.ExitAliasExpr(AliasExprNode { n })
+        case "dropExpr": listener.ExitDropExpr(DropExprNode { n })
+        case "hoistExpr": listener.ExitHoistExpr(HoistExprNode { n })
+        case "separatedExpr": listener.ExitSeparatedExpr(SeparatedExprNode { n })
+        case "quantifierExpr": listener.ExitQuantifierExpr(QuantifierExprNode { n })
+        case "repeatExpr": listener.ExitRepeatExpr(RepeatExprNode { n })
+        case "groupExpr": listener.ExitGroupExpr(GroupExprNode { n })
+        case "templateExpr": listener.ExitTemplateExpr(TemplateExprNode { n })
+        case "identifierExpr": listener.ExitIdentifierExpr(IdentifierExprNode { n })
+        case "stringExpr": listener.ExitStringExpr(StringExprNode { n })
+        case "nocaseStringExpr": listener.ExitNocaseStringExpr(NocaseStringExprNode { n })
+        case "classExpr": listener.ExitClassExpr(ClassExprNode { n })
+        case "errorExpr": listener.ExitErrorExpr(ErrorExprNode { n })
+        case "anyExpr": listener.ExitAnyExpr(AnyExprNode { n })
+    }
+}
+
+// Wraps a parse tree node in the typed node of its visitor, other children are returned unchanged.
+func wrapNode(child ParseTreeChild) ParseTreeChild {
+    if n, ok := child.(*ParseTreeNode); ok {
+        switch n.data.visitor {
+        case "grammar": return GrammarNode { n }
+        case "ruleStmt": return RuleStmtNode { n }
+        case "precedenceStmt": return PrecedenceStmtNode { n }
+        case "tokenStmt": return TokenStmtNode { n }
+        case "fragmentStmt": return FragmentStmtNode { n }
+        case "modeStmt": return ModeStmtNode { n }
+        case "importStmt": return ImportStmtNode { n }
+        case "startStmt": return StartStmtNode { n }
+        case "optionStmt": return OptionStmtNode { n }
+        case "stmt": return stmtNode { n }
+        case "skipAction": return SkipActionNode { n }
+        case "pushModeAction": return PushModeActionNode { n }
+        case "popModeAction": return PopModeActionNode { n }
+        case "modeAction": return ModeActionNode { n }
+        case "nocaseAction": return NocaseActionNode { n }
+        case "channelAction": return ChannelActionNode { n }
+        case "unionExpr": return UnionExprNode { n }
+        case "labelExpr": return LabelExprNode { n }
+        case "concatExpr": return ConcatExprNode { n }
+        case "differenceExpr": return DifferenceExprNode { n }
+        case "intersectionExpr": return IntersectionExprNode { n }
+        case "aliasExpr": return AliasExprNode { n }
+        case "dropExpr": return DropExprNode { n }
+        case "hoistExpr": return HoistExprNode { n }
+        case "separatedExpr": return SeparatedExprNode { n }
+        case "quantifierExpr": return QuantifierExprNode { n }
+        case "repeatExpr": return RepeatExprNode { n }
+        case "groupExpr": return GroupExprNode { n }
+        case "templateExpr": return TemplateExprNode { n }
+        case "identifierExpr": return IdentifierExprNode { n }
+        case "stringExpr": return StringExprNode { n }
+        case "nocaseStringExpr": return NocaseStringExprNode { n }
+        case "classExpr": return ClassExprNode { n }
+        case "errorExpr": return ErrorExprNode { n }
+        case "anyExpr": return AnyExprNode { n }
+        }
+    }
+    return child
+}
+
+// Node interface implemented by the typed nodes that rule stmt may derive.
+type StmtNode interface { ParseTreeChild; ParseTree() *ParseTreeNode; isStmtNode() }
+
+// Node interface implemented by the typed nodes that rule action may derive.
+type ActionNode interface { ParseTreeChild; ParseTree() *ParseTreeNode; isActionNode() }
+
+// Node interface implemented by the typed nodes that rule expr may derive.
+type ExprNode interface { ParseTreeChild; ParseTree() *ParseTreeNode; isExprNode() }
+
+// Typed node passed to VisitGrammar.
+type GrammarNode struct { *ParseTreeNode }
+
+// Typed node passed to VisitRuleStmt.
+type RuleStmtNode struct { *ParseTreeNode }
+func (RuleStmtNode) isStmtNode() { }
+func (n RuleStmtNode) RULE() Token { t, _ := n.GetAlias("RULE").(Token); return t }
+func (n RuleStmtNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n RuleStmtNode) I() *Token { if t, ok := n.GetAlias("i").(Token); ok { return &t }; return nil }
+func (n RuleStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitPrecedenceStmt.
+type PrecedenceStmtNode struct { *ParseTreeNode }
+func (PrecedenceStmtNode) isStmtNode() { }
+func (n PrecedenceStmtNode) PRECEDENCE() Token { t, _ := n.GetAlias("PRECEDENCE").(Token); return t }
+func (n PrecedenceStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitTokenStmt.
+type TokenStmtNode struct { *ParseTreeNode }
+func (TokenStmtNode) isStmtNode() { }
+func (n TokenStmtNode) TOKEN() Token { t, _ := n.GetAlias("TOKEN").(Token); return t }
+func (n TokenStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitFragmentStmt.
+type FragmentStmtNode struct { *ParseTreeNode }
+func (FragmentStmtNode) isStmtNode() { }
+func (n FragmentStmtNode) FRAGMENT() Token { t, _ := n.GetAlias("FRAGMENT").(Token); return t }
+func (n FragmentStmtNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n FragmentStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitModeStmt.
+type ModeStmtNode struct { *ParseTreeNode }
+func (ModeStmtNode) isStmtNode() { }
+func (n ModeStmtNode) MODE() Token { t, _ := n.GetAlias("MODE").(Token); return t }
+func (n ModeStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitImportStmt.
+type ImportStmtNode struct { *ParseTreeNode }
+func (ImportStmtNode) isStmtNode() { }
+func (n ImportStmtNode) IMPORT() Token { t, _ := n.GetAlias("IMPORT").(Token); return t }
+func (n ImportStmtNode) STRING() Token { t, _ := n.GetAlias("STRING").(Token); return t }
+
+// Typed node passed to VisitStartStmt.
+type StartStmtNode struct { *ParseTreeNode }
+func (StartStmtNode) isStmtNode() { }
+func (n StartStmtNode) START() Token { t, _ := n.GetAlias("START").(Token); return t }
+func (n StartStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitOptionStmt.
+type OptionStmtNode struct { *ParseTreeNode }
+func (OptionStmtNode) isStmtNode() { }
+func (n OptionStmtNode) OPTION() Token { t, _ := n.GetAlias("OPTION").(Token); return t }
+func (n OptionStmtNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitStmt.
+type stmtNode struct { *ParseTreeNode }
+func (stmtNode) isStmtNode() { }
+
+// Typed node passed to VisitSkipAction.
+type SkipActionNode struct { *ParseTreeNode }
+func (SkipActionNode) isActionNode() { }
+func (n SkipActionNode) SKIP() Token { t, _ := n.GetAlias("SKIP").(Token); return t }
+
+// Typed node passed to VisitPushModeAction.
+type PushModeActionNode struct { *ParseTreeNode }
+func (PushModeActionNode) isActionNode() { }
+func (n PushModeActionNode) PUSH_MODE() Token { t, _ := n.GetAlias("PUSH_MODE").(Token); return t }
+func (n PushModeActionNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitPopModeAction.
+type PopModeActionNode struct { *ParseTreeNode }
+func (PopModeActionNode) isActionNode() { }
+func (n PopModeActionNode) POP_MODE() Token { t, _ := n.GetAlias("POP_MODE").(Token); return t }
+
+// Typed node passed to VisitModeAction.
+type ModeActionNode struct { *ParseTreeNode }
+func (ModeActionNode) isActionNode() { }
+func (n ModeActionNode) MODE() Token { t, _ := n.GetAlias("MODE").(Token); return t }
+func (n ModeActionNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitNocaseAction.
+type NocaseActionNode struct { *ParseTreeNode }
+func (NocaseActionNode) isActionNode() { }
+func (n NocaseActionNode) NOCASE() Token { t, _ := n.GetAlias("NOCASE").(Token); return t }
+
+// Typed node passed to VisitChannelAction.
+type ChannelActionNode struct { *ParseTreeNode }
+func (ChannelActionNode) isActionNode() { }
+func (n ChannelActionNode) CHANNEL() Token { t, _ := n.GetAlias("CHANNEL").(Token); return t }
+func (n ChannelActionNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitUnionExpr.
+type UnionExprNode struct { *ParseTreeNode }
+func (UnionExprNode) isExprNode() { }
+func (n UnionExprNode) L() ExprNode { c, _ := wrapNode(n.GetAlias("l")).(ExprNode); return c }
+func (n UnionExprNode) R() ExprNode { c, _ := wrapNode(n.GetAlias("r")).(ExprNode); return c }
+
+// Typed node passed to VisitLabelExpr.
+type LabelExprNode struct { *ParseTreeNode }
+func (LabelExprNode) isExprNode() { }
+func (n LabelExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n LabelExprNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitConcatExpr.
+type ConcatExprNode struct { *ParseTreeNode }
+func (ConcatExprNode) isExprNode() { }
+func (n ConcatExprNode) L() ExprNode { c, _ := wrapNode(n.GetAlias("l")).(ExprNode); return c }
+func (n ConcatExprNode) R() ExprNode { c, _ := wrapNode(n.GetAlias("r")).(ExprNode); return c }
+
+// Typed node passed to VisitDifferenceExpr.
+type DifferenceExprNode struct { *ParseTreeNode }
+func (DifferenceExprNode) isExprNode() { }
+func (n DifferenceExprNode) L() ExprNode { c, _ := wrapNode(n.GetAlias("l")).(ExprNode); return c }
+func (n DifferenceExprNode) R() ExprNode { c, _ := wrapNode(n.GetAlias("r")).(ExprNode); return c }
+
+// Typed node passed to VisitIntersectionExpr.
+type IntersectionExprNode struct { *ParseTreeNode }
+func (IntersectionExprNode) isExprNode() { }
+func (n IntersectionExprNode) L() ExprNode { c, _ := wrapNode(n.GetAlias("l")).(ExprNode); return c }
+func (n IntersectionExprNode) R() ExprNode { c, _ := wrapNode(n.GetAlias("r")).(ExprNode); return c }
+
+// Typed node passed to VisitAliasExpr.
+type AliasExprNode struct { *ParseTreeNode }
+func (AliasExprNode) isExprNode() { }
+func (n AliasExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n AliasExprNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitDropExpr.
+type DropExprNode struct { *ParseTreeNode }
+func (DropExprNode) isExprNode() { }
+func (n DropExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+
+// Typed node passed to VisitHoistExpr.
+type HoistExprNode struct { *ParseTreeNode }
+func (HoistExprNode) isExprNode() { }
+func (n HoistExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+
+// Typed node passed to VisitSeparatedExpr.
+type SeparatedExprNode struct { *ParseTreeNode }
+func (SeparatedExprNode) isExprNode() { }
+func (n SeparatedExprNode) L() ExprNode { c, _ := wrapNode(n.GetAlias("l")).(ExprNode); return c }
+func (n SeparatedExprNode) Op() Token { t, _ := n.GetAlias("op").(Token); return t }
+func (n SeparatedExprNode) R() ExprNode { c, _ := wrapNode(n.GetAlias("r")).(ExprNode); return c }
+
+// Typed node passed to VisitQuantifierExpr.
+type QuantifierExprNode struct { *ParseTreeNode }
+func (QuantifierExprNode) isExprNode() { }
+func (n QuantifierExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n QuantifierExprNode) Op() Token { t, _ := n.GetAlias("op").(Token); return t }
+
+// Typed node passed to VisitRepeatExpr.
+type RepeatExprNode struct { *ParseTreeNode }
+func (RepeatExprNode) isExprNode() { }
+func (n RepeatExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n RepeatExprNode) Min() Token { t, _ := n.GetAlias("min").(Token); return t }
+
+// Typed node passed to VisitGroupExpr.
+type GroupExprNode struct { *ParseTreeNode }
+func (GroupExprNode) isExprNode() { }
+func (n GroupExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+
+// Typed node passed to VisitTemplateExpr.
+type TemplateExprNode struct { *ParseTreeNode }
+func (TemplateExprNode) isExprNode() { }
+func (n TemplateExprNode) Expr() ExprNode { c, _ := wrapNode(n.GetAlias("expr")).(ExprNode); return c }
+func (n TemplateExprNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitIdentifierExpr.
+type IdentifierExprNode struct { *ParseTreeNode }
+func (IdentifierExprNode) isExprNode() { }
+func (n IdentifierExprNode) Id() Token { t, _ := n.GetAlias("id").(Token); return t }
+
+// Typed node passed to VisitStringExpr.
+type StringExprNode struct { *ParseTreeNode }
+func (StringExprNode) isExprNode() { }
+func (n StringExprNode) STRING() Token { t, _ := n.GetAlias("STRING").(Token); return t }
+
+// Typed node passed to VisitNocaseStringExpr.
+type NocaseStringExprNode struct { *ParseTreeNode }
+func (NocaseStringExprNode) isExprNode() { }
+func (n NocaseStringExprNode) ISTRING() Token { t, _ := n.GetAlias("ISTRING").(Token); return t }
+
+// Typed node passed to VisitClassExpr.
+type ClassExprNode struct { *ParseTreeNode }
+func (ClassExprNode) isExprNode() { }
+func (n ClassExprNode) CLASS() Token { t, _ := n.GetAlias("CLASS").(Token); return t }
+
+// Typed node passed to VisitErrorExpr.
+type ErrorExprNode struct { *ParseTreeNode }
+func (ErrorExprNode) isExprNode() { }
+func (n ErrorExprNode) ERROR() Token { t, _ := n.GetAlias("ERROR").(Token); return t }
+
+// Typed node passed to VisitAnyExpr.
+type AnyExprNode struct { *ParseTreeNode }
+func (AnyExprNode) isExprNode() { }
+
 func (n *ParseTreeNode) Stmt() ParseTreeChild { return n.GetAlias("stmt") }
+func (n *ParseTreeNode) Id() ParseTreeChild { return n.GetAlias("id") }
 func (n *ParseTreeNode) RULE() ParseTreeChild { return n.GetAlias("RULE") }
-func (n *ParseTreeNode) IDENTIFIER() ParseTreeChild { return n.GetAlias("IDENTIFIER") }
 func (n *ParseTreeNode) Expr() ParseTreeChild { return n.GetAlias("expr") }
+func (n *ParseTreeNode) I() ParseTreeChild { return n.GetAlias("i") }
+func (n *ParseTreeNode) P() ParseTreeChild { return n.GetAlias("p") }
 func (n *ParseTreeNode) A() ParseTreeChild { return n.GetAlias("a") }
+func (n *ParseTreeNode) T() ParseTreeChild { return n.GetAlias("t") }
 func (n *ParseTreeNode) PRECEDENCE() ParseTreeChild { return n.GetAlias("PRECEDENCE") }
 func (n *ParseTreeNode) V() ParseTreeChild { return n.GetAlias("v") }
-func (n *ParseTreeNode) SKIP() ParseTreeChild { return n.GetAlias("SKIP") }
-func (n *ParseTreeNode) S() ParseTreeChild { return n.GetAlias("s") }
+func (n *ParseTreeNode) Action() ParseTreeChild { return n.GetAlias("action") }
 func (n *ParseTreeNode) TOKEN() ParseTreeChild { return n.GetAlias("TOKEN") }
 func (n *ParseTreeNode) FRAGMENT() ParseTreeChild { return n.GetAlias("FRAGMENT") }
+func (n *ParseTreeNode) MODE() ParseTreeChild { return n.GetAlias("MODE") }
+func (n *ParseTreeNode) IMPORT() ParseTreeChild { return n.GetAlias("IMPORT") }
+func (n *ParseTreeNode) STRING() ParseTreeChild { return n.GetAlias("STRING") }
+func (n *ParseTreeNode) START() ParseTreeChild { return n.GetAlias("START") }
+func (n *ParseTreeNode) OPTION() ParseTreeChild { return n.GetAlias("OPTION") }
+func (n *ParseTreeNode) SKIP() ParseTreeChild { return n.GetAlias("SKIP") }
+func (n *ParseTreeNode) PUSH_MODE() ParseTreeChild { return n.GetAlias("PUSH_MODE") }
+func (n *ParseTreeNode) POP_MODE() ParseTreeChild { return n.GetAlias("POP_MODE") }
+func (n *ParseTreeNode) NOCASE() ParseTreeChild { return n.GetAlias("NOCASE") }
+func (n *ParseTreeNode) CHANNEL() ParseTreeChild { return n.GetAlias("CHANNEL") }
 func (n *ParseTreeNode) L() ParseTreeChild { return n.GetAlias("l") }
 func (n *ParseTreeNode) R() ParseTreeChild { return n.GetAlias("r") }
-func (n *ParseTreeNode) P() ParseTreeChild { return n.GetAlias("p") }
 func (n *ParseTreeNode) Op() ParseTreeChild { return n.GetAlias("op") }
-func (n *ParseTreeNode) STRING() ParseTreeChild { return n.GetAlias("STRING") }
+func (n *ParseTreeNode) Max() ParseTreeChild { return n.GetAlias("max") }
+func (n *ParseTreeNode) M() ParseTreeChild { return n.GetAlias("m") }
+func (n *ParseTreeNode) Min() ParseTreeChild { return n.GetAlias("min") }
+func (n *ParseTreeNode) ISTRING() ParseTreeChild { return n.GetAlias("ISTRING") }
 func (n *ParseTreeNode) CLASS() ParseTreeChild { return n.GetAlias("CLASS") }
 func (n *ParseTreeNode) ERROR() ParseTreeChild { return n.GetAlias("ERROR") }
+// Returns the underlying parse tree node, which is promoted to every typed node.
+func (n *ParseTreeNode) ParseTree() *ParseTreeNode { return n }
 
 // Given an alias, return the corresponding parse tree node child based on the production data.
 func (n *ParseTreeNode) GetAlias(alias string) ParseTreeChild {
@@ -332,6 +1485,20 @@ func (n *ParseTreeNode) GetAlias(alias string) ParseTreeChild {
 // Prints the parse tree to the standard output.
 func (n *ParseTreeNode) Print() { fmt.Println(n.string("")) }
 
+func (n *AmbiguityNode) string(indent string) string {
+    alternatives := make([]string, len(n.Alternatives))
+    next := indent + "  "
+    for i, c := range n.Alternatives {
+        str := "\n"
+        if c == nil {
+            str += fmt.Sprintf("%s<nil>", next)
+        } else {
+            str += c.string(next)
+        }
+        alternatives[i] = str
+    }
+    return fmt.Sprintf("%s{ambiguity}%s", indent, strings.Join(alternatives, ""))
+}
 func (t Token) string(indent string) string { return fmt.Sprintf("%s<%s %s>", indent, t.Type, t.Value) }
 func (n *ParseTreeNode) string(indent string) string {
     children := make([]string, len(n.Children))
@@ -2,6 +2,8 @@ package lynn
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"unsafe"
@@ -18,10 +20,16 @@ type LRState struct {
 }
 
 // LR(1) parse table. Represents action table and goto table.
+// The first states of the table are the start states, which parse the corresponding non-terminals in Starts.
+// Tables generated for GLR parsing keep the actions that conflict with the action table, otherwise Conflicts is nil.
+// Errors map the tokens rejected by non-associative precedence levels in each state to the name of the level.
 type LRParseTable struct {
-    Grammar *Grammar
-    Action  []map[Terminal]ActionEntry
-    Goto    []map[NonTerminal]int
+    Grammar   *Grammar
+    Starts    []NonTerminal
+    Action    []map[Terminal]ActionEntry
+    Goto      []map[NonTerminal]int
+    Conflicts []map[Terminal][]ActionEntry
+    Errors    []map[Terminal]string
 }
 
 // Action type enum. Either SHIFT, REDUCE, or ACCEPT.
@@ -33,27 +41,69 @@ type ActionEntry struct {
     Value int // For SHIFT actions, value represents a state identifier, for REDUCE actions, a production identifier
 }
 
+// Conflict resolution type enum. Either UNRESOLVED, RESOLVE_SHIFT, RESOLVE_REDUCE, or RESOLVE_ERROR.
+type ResolutionType uint
+const (UNRESOLVED ResolutionType = iota; RESOLVE_SHIFT; RESOLVE_REDUCE; RESOLVE_ERROR)
+
+// Parse table construction mode enum. Either LALR_TABLE, LR1_TABLE, or MINIMAL_LR1_TABLE.
+// Canonical LR(1) tables never merge states, while minimal LR(1) tables only merge states if no new conflicts are introduced.
+type TableMode uint
+const (LALR_TABLE TableMode = iota; LR1_TABLE; MINIMAL_LR1_TABLE)
+
 // LALR parser generator struct. Converts a given grammar to an LR(1) parse table.
 type LALRParserGenerator struct {
+    mode      TableMode
+    glr       bool
     grammar   *Grammar
-    augmented *Production
+    augmented []*Production
     first     map[Symbol]map[Terminal]struct{}
+    rules     map[NonTerminal][]*Production // Productions of each non-terminal
+    operators map[Terminal][]int            // Orders of the non-associative precedence levels each token is an operator of
 }
 
-// Returns a new LALR parser generator struct.
-func NewLALRParserGenerator() *LALRParserGenerator { return &LALRParserGenerator { } }
+// Placeholder lookahead used to find which lookaheads propagate between LR(0) items, never a terminal in the grammar.
+const PROPAGATE_TERMINAL Terminal = "#"
+
+// Returns a new LALR parser generator struct, which constructs parse tables using the given mode.
+// Parse tables generated for GLR parsing keep all conflicting actions instead of reporting conflicts.
+func NewLALRParserGenerator(mode TableMode, glr bool) *LALRParserGenerator {
+    return &LALRParserGenerator { mode: mode, glr: glr }
+}
 // Converts a grammar definition to an LR(1) parse table.
 func (g *LALRParserGenerator) Generate(grammar *Grammar) LRParseTable {
-    // Initialize generator with augmented grammar
+    g.initialize(grammar)
+    // Construct states depending on the construction mode
+    // LALR(1) states are constructed from LR(0) states, while other modes begin from the canonical LR(1) states
+    var states []*LRState
+    switch g.mode {
+    case LALR_TABLE: states = g.buildLALRStates()
+    case LR1_TABLE:  states = g.buildLR1States()
+    case MINIMAL_LR1_TABLE:
+        states = g.buildLR1States()
+        states = mergeStates(states, g.findCompatibleStates(states))
+    }
+    // Generate parse table and pass to shift-reduce parser, states are numbered canonically so output is reproducible
+    table := g.buildParseTable(g.orderStates(states))
+    return table
+}
+
+// Initializes the generator with the augmented grammar, the productions of each non-terminal, and the FIRST sets.
+func (g *LALRParserGenerator) initialize(grammar *Grammar) {
     g.grammar = grammar
     g.augmented = grammar.Augment()
-    // Find first set and construct LALR(1) states from LR(1) states
+    g.rules = make(map[NonTerminal][]*Production)
+    for _, p := range grammar.Productions { g.rules[p.Left] = append(g.rules[p.Left], p) }
     g.first = make(map[Symbol]map[Terminal]struct{})
     g.findFirst()
-    states := buildLALRStates(g.buildLR1States())
-    // Generate parse table and pass to shift-reduce parser
-    table := g.buildParseTable(states)
-    return table
+    // Operators of non-associative infix productions, which are rewritten to the form E_k -> E_k ... E_{k + 1}
+    // The operator of a production is any token that may follow its first operand
+    g.operators = make(map[Terminal][]int)
+    for p, level := range grammar.ProductionPrecedence {
+        if level.Associativity != NON_ASSOC || len(p.Right) < 2 || p.Right[0] != p.Left { continue }
+        for t := range g.findSequenceFirst(p.Right[1:]) {
+            if t != EPSILON && !slices.Contains(g.operators[t], level.Order) { g.operators[t] = append(g.operators[t], level.Order) }
+        }
+    }
 }
 
 // Computes the FIRST sets of all symbols in the grammar provided by the parser.
@@ -80,6 +130,8 @@ func (g *LALRParserGenerator) findRuleFirst(left NonTerminal, rule []Symbol) boo
     for _, s := range rule {
         for f := range g.first[s] {
             // Add all elements in FIRST set of symbol to FIRST set of production LHS non-terminal
+            // Epsilon is excluded since the LHS is only nullable if all symbols are nullable
+            if f == EPSILON { continue }
             if _, ok := g.first[left][f]; !ok { g.first[left][f] = struct{}{}; changed = true }
         }
         // All elements in FIRST set have been found if symbol is non-nullable
@@ -93,8 +145,8 @@ func (g *LALRParserGenerator) findRuleFirst(left NonTerminal, rule []Symbol) boo
 func (g *LALRParserGenerator) findSequenceFirst(sequence []Symbol) map[Terminal]struct{} {
     first := make(map[Terminal]struct{})
     for _, s := range sequence {
-        // Add all elements in FIRST set of symbol to set
-        for f := range g.first[s] { first[f] = struct{}{} }
+        // Add all elements in FIRST set of symbol to set, excluding epsilon
+        for f := range g.first[s] { if f != EPSILON { first[f] = struct{}{} } }
         // All elements in FIRST set have been found if symbol is non-nullable
         if _, ok := g.first[s][EPSILON]; !ok { return first }
     }
@@ -120,9 +172,7 @@ func (g *LALRParserGenerator) findClosure(items map[LR1Item]struct{}) map[LR1Ite
         first := g.findSequenceFirst(right[item.Dot + 1:])
         if _, ok := first[EPSILON]; ok { delete(first, EPSILON); first[item.Lookahead] = struct{}{} }
         // Look for all productions with the given non terminal as its LHS
-        for i := range g.grammar.Productions {
-            production := g.grammar.Productions[i]
-            if production.Left != t { continue }
+        for _, production := range g.rules[t] {
             // Add productions to the work list, starting dot at the start and create copies for each terminal in the first set
             for lookahead := range first {
                 i := LR1Item { production, 0, lookahead }
@@ -149,15 +199,18 @@ func (g *LALRParserGenerator) findGoto(items map[LR1Item]struct{}, symbol Symbol
 }
 
 // Constructs the canonical collection of LR(1) item sets.
-// The first state in the list is the start state (LR(1) item set that contains augmented start production).
+// The first states in the list are the start states (LR(1) item sets that contain each augmented start production).
 func (g *LALRParserGenerator) buildLR1States() []*LRState {
-    // Find closure of initial item
-    closure := g.findClosure(map[LR1Item]struct{} { { g.augmented, 0, EOF_TERMINAL }: {} })
-    // Initialize work list with state associated with set
-    start := &LRState { closure, make(map[Symbol]*LRState) }
-    states := map[string]*LRState { getLR1ItemStateKey(start.Items): start } // Register state key to state object
+    states, list := make(map[string]*LRState), make([]*LRState, 0, len(g.augmented))
+    for _, production := range g.augmented {
+        // Find closure of initial item
+        closure := g.findClosure(map[LR1Item]struct{} { { production, 0, EOF_TERMINAL }: {} })
+        // Initialize work list with state associated with set
+        start := &LRState { closure, make(map[Symbol]*LRState) }
+        states[getLR1ItemStateKey(start.Items)] = start // Register state key to state object
+        list = append(list, start)
+    }
     // Iterative implementation, process items in list list until no new items are added
-    list := []*LRState { start }
     for index := 0; index < len(list); {
         state := list[index]; index++
         // Find all outgoing transition symbols from current state
@@ -188,21 +241,198 @@ func (g *LALRParserGenerator) buildLR1States() []*LRState {
     return list
 }
 
-// Merges LR(1) states with identical LR(0) cores to create LALR(1) states.
-func buildLALRStates(states []*LRState) []*LRState {
-    // Create replacement map according to partition LR(1) of states based on their LR(0) cores
-    representatives, merge := make(map[string]*LRState), make(map[*LRState]*LRState)
+// Constructs LALR(1) states from the LR(0) states of the grammar, without constructing the canonical collection of LR(1) item sets.
+// Lookaheads of kernel items are either generated spontaneously or propagated from the kernel items of preceding states.
+// The first states in the list are the start states (LR(0) item sets that contain each augmented start production).
+func (g *LALRParserGenerator) buildLALRStates() []*LRState {
+    // LR(0) state struct. Holds the kernel items of the state, which determine the state's closure.
+    type LR0State struct {
+        Kernel      []LR0Item
+        Transitions map[Symbol]*LR0State
+    }
+    states, list := make(map[string]*LR0State), make([]*LR0State, 0, len(g.augmented))
+    for _, production := range g.augmented {
+        start := &LR0State { []LR0Item { { production, 0 } }, make(map[Symbol]*LR0State) }
+        states[getLR0ItemStateKey(map[LR0Item]struct{} { { production, 0 }: {} })] = start
+        list = append(list, start)
+    }
+    // Construct LR(0) states, kernels of next states are found by advancing the items in the closure of the current state
+    for index := 0; index < len(list); index++ {
+        state := list[index]
+        kernels, order := make(map[Symbol]map[LR0Item]struct{}), make([]Symbol, 0)
+        for _, item := range g.findLR0Closure(state.Kernel) {
+            right := item.Production.Right
+            if item.Dot >= len(right) { continue }
+            symbol := right[item.Dot]
+            if kernels[symbol] == nil { kernels[symbol] = make(map[LR0Item]struct{}); order = append(order, symbol) }
+            kernels[symbol][LR0Item { item.Production, item.Dot + 1 }] = struct{}{}
+        }
+        for _, symbol := range order {
+            key := getLR0ItemStateKey(kernels[symbol]); next := states[key]
+            if next == nil {
+                next = &LR0State { slices.Collect(maps.Keys(kernels[symbol])), make(map[Symbol]*LR0State) }
+                states[key] = next
+                list = append(list, next)
+            }
+            state.Transitions[symbol] = next
+        }
+    }
+    // Find spontaneous lookaheads and propagation links between kernel items
+    // The closure of each kernel item with a placeholder lookahead determines the lookaheads of the items it advances to
+    type kernelItem struct { state *LR0State; item LR0Item }
+    lookaheads, links := make(map[kernelItem]map[Terminal]struct{}), make(map[kernelItem][]kernelItem)
+    for _, state := range list {
+        for _, item := range state.Kernel { lookaheads[kernelItem { state, item }] = make(map[Terminal]struct{}) }
+    }
+    for i := range g.augmented { lookaheads[kernelItem { list[i], list[i].Kernel[0] }][EOF_TERMINAL] = struct{}{} }
+    for _, state := range list {
+        for _, kernel := range state.Kernel {
+            from := kernelItem { state, kernel }
+            closure := g.findClosure(map[LR1Item]struct{} { { kernel.Production, kernel.Dot, PROPAGATE_TERMINAL }: {} })
+            for item := range closure {
+                right := item.Production.Right
+                if item.Dot >= len(right) { continue }
+                to := kernelItem { state.Transitions[right[item.Dot]], LR0Item { item.Production, item.Dot + 1 } }
+                if item.Lookahead == PROPAGATE_TERMINAL {
+                    links[from] = append(links[from], to)
+                } else {
+                    lookaheads[to][item.Lookahead] = struct{}{}
+                }
+            }
+        }
+    }
+    // Propagate lookaheads along links until no new lookaheads are added
+    work := make([]kernelItem, 0, len(lookaheads))
+    for _, state := range list {
+        for _, item := range state.Kernel { work = append(work, kernelItem { state, item }) }
+    }
+    for len(work) > 0 {
+        from := work[len(work) - 1]; work = work[:len(work) - 1]
+        for _, to := range links[from] {
+            changed := false
+            for t := range lookaheads[from] {
+                if _, ok := lookaheads[to][t]; !ok { lookaheads[to][t] = struct{}{}; changed = true }
+            }
+            if changed { work = append(work, to) }
+        }
+    }
+    // Create LALR(1) states from the closure of each state's kernel items and their lookaheads
+    result, ids := make([]*LRState, len(list)), make(map[*LR0State]int, len(list))
+    for i, state := range list {
+        kernel := make(map[LR1Item]struct{})
+        for _, item := range state.Kernel {
+            for t := range lookaheads[kernelItem { state, item }] { kernel[LR1Item { item.Production, item.Dot, t }] = struct{}{} }
+        }
+        result[i], ids[state] = &LRState { g.findClosure(kernel), make(map[Symbol]*LRState) }, i
+    }
+    for i, state := range list {
+        for symbol, next := range state.Transitions { result[i].Transitions[symbol] = result[ids[next]] }
+    }
+    return result
+}
+
+// Computes the closure set of a set of LR(0) items.
+func (g *LALRParserGenerator) findLR0Closure(items []LR0Item) []LR0Item {
+    closure, visited := slices.Clone(items), make(map[NonTerminal]struct{})
+    for i := 0; i < len(closure); i++ {
+        // Add the initial items of each non-terminal after the dot once
+        right := closure[i].Production.Right
+        if closure[i].Dot >= len(right) { continue }
+        t, ok := right[closure[i].Dot].(NonTerminal); if !ok { continue }
+        if _, ok := visited[t]; ok { continue }
+        visited[t] = struct{}{}
+        for _, production := range g.rules[t] { closure = append(closure, LR0Item { production, 0 }) }
+    }
+    return closure
+}
+
+// Partitions LR(1) states based on their LR(0) cores, merging states with identical cores creates LALR(1) states.
+func findCores(states []*LRState) map[*LRState]int {
+    cores, blocks := make(map[string]int), make(map[*LRState]int, len(states))
+    for _, state := range states {
+        key := getCoreKey(state)
+        if _, ok := cores[key]; !ok { cores[key] = len(cores) }
+        blocks[state] = cores[key]
+    }
+    return blocks
+}
+
+// Partitions LR(1) states so that states of a block have identical LR(0) cores and may be merged without introducing conflicts.
+// Blocks are split until merging a block introduces no new reduce/reduce conflicts, and each state of a block transitions to the same blocks.
+func (g *LALRParserGenerator) findCompatibleStates(states []*LRState) map[*LRState]int {
+    // Find productions reduced on each lookahead for all states
+    reductions := make(map[*LRState]map[Terminal]map[*Production]struct{}, len(states))
+    for _, state := range states {
+        r := make(map[Terminal]map[*Production]struct{})
+        for item := range state.Items {
+            if item.Dot < len(item.Production.Right) { continue }
+            if r[item.Lookahead] == nil { r[item.Lookahead] = make(map[*Production]struct{}) }
+            r[item.Lookahead][item.Production] = struct{}{}
+        }
+        reductions[state] = r
+    }
+    // Merged block struct. Holds the productions reduced on each lookahead and the largest number reduced by a single state.
+    type block struct {
+        id         int
+        reductions map[Terminal]map[*Production]struct{}
+        conflicts  map[Terminal]int
+    }
+    blocks, n := findCores(states), -1
+    for {
+        // Assign each state to the first block of its previous block that it may be merged with without introducing conflicts
+        candidates, split, count := make(map[int][]*block), make(map[*LRState]int, len(states)), 0
+        for _, state := range states {
+            var target *block
+            for _, b := range candidates[blocks[state]] {
+                compatible := true
+                for t, productions := range reductions[state] {
+                    // Reduce/reduce conflicts are only allowed if one of the merged states already had a conflict of the same size
+                    union := len(b.reductions[t])
+                    for p := range productions { if _, ok := b.reductions[t][p]; !ok { union++ } }
+                    if union > 1 && union > max(b.conflicts[t], len(productions)) { compatible = false; break }
+                }
+                if compatible { target = b; break }
+            }
+            if target == nil {
+                target = &block { count, make(map[Terminal]map[*Production]struct{}), make(map[Terminal]int) }
+                candidates[blocks[state]] = append(candidates[blocks[state]], target)
+                count++
+            }
+            for t, productions := range reductions[state] {
+                if target.reductions[t] == nil { target.reductions[t] = make(map[*Production]struct{}) }
+                for p := range productions { target.reductions[t][p] = struct{}{} }
+                target.conflicts[t] = max(target.conflicts[t], len(productions))
+            }
+            split[state] = target.id
+        }
+        // Split blocks further so that states in the same block transition to the same blocks
+        keys, refined := make(map[string]int), make(map[*LRState]int, len(states))
+        for _, state := range states {
+            transitions := make([]string, 0, len(state.Transitions))
+            for symbol, next := range state.Transitions { transitions = append(transitions, fmt.Sprintf("%s:%d", symbol, split[next])) }
+            slices.Sort(transitions)
+            key := fmt.Sprintf("%d %s", split[state], strings.Join(transitions, " "))
+            if _, ok := keys[key]; !ok { keys[key] = len(keys) }
+            refined[state] = keys[key]
+        }
+        // Blocks are only ever split, so the partition is stable once the number of blocks is unchanged
+        stable := len(keys) == n
+        blocks, n = refined, len(keys)
+        if stable { break }
+    }
+    return blocks
+}
+
+// Merges LR(1) states that belong to the same block of a partition.
+// Transitions of merged states must lead to states of the same block, which holds for states with identical LR(0) cores.
+func mergeStates(states []*LRState, blocks map[*LRState]int) []*LRState {
+    // Create replacement map, the first state of each block is assigned as the block's representative
+    representatives, merge := make(map[int]*LRState), make(map[*LRState]*LRState)
     for _, state := range states {
-        // Compute LR(0) core
-        core := make(map[LR0Item]struct{})
-        for item := range state.Items { core[LR0Item { item.Production, item.Dot }] = struct{}{} }
-        // If the core has not been seen before, assign the current state as the subset's representative
-        // Otherwise map current state to its representative
-        key := getLR0ItemStateKey(core)
-        if representative := representatives[key]; representative != nil {
+        if representative := representatives[blocks[state]]; representative != nil {
             merge[state] = representative
         } else {
-            representatives[key] = state
+            representatives[blocks[state]] = state
         }
     }
     // Merge states according to replacement map
@@ -221,7 +451,6 @@ func buildLALRStates(states []*LRState) []*LRState {
         // If state is not a representative, its data is merged into its corresponding representative
         for item := range state.Items { representative.Items[item] = struct{}{} }
         for symbol, next := range state.Transitions {
-            // States cannot be successfully merged if multiple transitions on the same symbol exist that go to different states
             if r := merge[next]; r != nil { next = r }
             representative.Transitions[symbol] = next
         }
@@ -229,6 +458,278 @@ func buildLALRStates(states []*LRState) []*LRState {
     return merged
 }
 
+// Orders LR(1) states canonically, in breadth-first order from the start states.
+// Transitions of each state are followed in the order that terminals and non-terminals are listed in the grammar.
+func (g *LALRParserGenerator) orderStates(states []*LRState) []*LRState {
+    symbols := make([]Symbol, 0, len(g.grammar.Terminals) + len(g.grammar.NonTerminals))
+    for _, t := range g.grammar.Terminals { symbols = append(symbols, t) }
+    for _, t := range g.grammar.NonTerminals { symbols = append(symbols, t) }
+    ordered, visited := slices.Clone(states[:len(g.augmented)]), make(map[*LRState]struct{}, len(states))
+    for _, state := range ordered { visited[state] = struct{}{} }
+    for i := 0; i < len(ordered); i++ {
+        for _, symbol := range symbols {
+            next, ok := ordered[i].Transitions[symbol]; if !ok { continue }
+            if _, ok := visited[next]; !ok { visited[next] = struct{}{}; ordered = append(ordered, next) }
+        }
+    }
+    return ordered
+}
+
+// Returns a note if a reduce/reduce conflict was introduced by merging states with identical LR(0) cores.
+// This is the case if none of the canonical LR(1) states with the same core as the state have the conflict.
+// Canonical states are only constructed along paths into the conflicting core, other states cannot reach it.
+func (g *LALRParserGenerator) describeConflict(states []*LRState, state *LRState, t Terminal, a, b *Production) string {
+    if g.mode == LR1_TABLE { return "" }
+    // Find the cores of states that reach the conflicting core by following transitions backwards
+    target, predecessors := getCoreKey(state), make(map[*LRState][]*LRState, len(states))
+    for _, s := range states {
+        for _, next := range s.Transitions { predecessors[next] = append(predecessors[next], s) }
+    }
+    reaching, work := make(map[string]struct{}), make([]*LRState, 0)
+    for _, s := range states {
+        if getCoreKey(s) == target { work = append(work, s) }
+    }
+    for visited := make(map[*LRState]struct{}); len(work) > 0; {
+        s := work[len(work) - 1]; work = work[:len(work) - 1]
+        if _, ok := visited[s]; ok { continue }
+        visited[s] = struct{}{}; reaching[getCoreKey(s)] = struct{}{}
+        work = append(work, predecessors[s]...)
+    }
+    // Construct the canonical LR(1) states whose cores reach the conflicting core
+    seen, list := make(map[string]struct{}), make([]map[LR1Item]struct{}, 0)
+    add := func (items map[LR1Item]struct{}) {
+        if _, ok := reaching[getCoreKey(&LRState { Items: items })]; !ok { return }
+        key := getLR1ItemStateKey(items)
+        if _, ok := seen[key]; !ok { seen[key] = struct{}{}; list = append(list, items) }
+    }
+    for _, production := range g.augmented {
+        add(g.findClosure(map[LR1Item]struct{} { { production, 0, EOF_TERMINAL }: {} }))
+    }
+    for index := 0; index < len(list); index++ {
+        items := list[index]
+        _, x := items[LR1Item { a, len(a.Right), t }]
+        _, y := items[LR1Item { b, len(b.Right), t }]
+        if x && y { return "" }
+        transitions := make(map[Symbol]struct{})
+        for item := range items {
+            if item.Dot < len(item.Production.Right) { transitions[item.Production.Right[item.Dot]] = struct{}{} }
+        }
+        for symbol := range transitions { add(g.findGoto(items, symbol)) }
+    }
+    return " (introduced by merging states with identical LR(0) cores)"
+}
+
+// Returns a counterexample of a conflict on a token, followed by the LR(1) items of the conflicting state.
+// The reduce item of the production is reached by the shortest prefix, then the other item (the shift items of the token if
+// other is nil) is searched for along the same prefix. If both derivations are followed by the same symbols, the counterexample
+// is unifying, otherwise an example is given for each derivation.
+func (g *LALRParserGenerator) explainConflict(states []*LRState, id int, t Terminal, p, other *Production) string {
+    var builder strings.Builder
+    state := states[id]
+    first := g.findShortestDerivation(states, state, func (item LR1Item) bool { return item == LR1Item { p, len(p.Right), t } })
+    if first != nil {
+        labels := [2]string { "Reduce derivation", "Shift derivation" }
+        // The other item must be an item of the conflicting production if it exists, or an item that shifts the token otherwise
+        var match func (item LR0Item, suffix []Symbol) bool
+        if other != nil {
+            labels = [2]string { "First reduce derivation", "Second reduce derivation" }
+            match = func (item LR0Item, suffix []Symbol) bool {
+                return item == LR0Item { other, len(other.Right) } && g.isFollowedBy(suffix, t)
+            }
+        } else {
+            match = func (item LR0Item, suffix []Symbol) bool {
+                return item.Dot < len(item.Production.Right) && item.Production.Right[item.Dot] == t
+            }
+        }
+        prefix := make([]Symbol, 0)
+        for _, item := range first { prefix = append(prefix, item.Production.Right[:item.Dot]...) }
+        second := g.findPrefixDerivation(first[0].Production, prefix, getDerivationSuffix(first), match)
+        if second == nil {
+            // Items of merged states may not be reachable by the same prefix
+            second = g.findShortestDerivation(states, state, func (item LR1Item) bool {
+                return match(LR0Item { item.Production, item.Dot }, []Symbol { item.Lookahead })
+            })
+        }
+        switch {
+        case second == nil:
+            fmt.Fprintf(&builder, "\n    Example: %s\n    %s: %s", formatExample(first), labels[0], formatDerivation(first))
+        case slices.Equal(getDerivationSuffix(first), getDerivationSuffix(second)) &&
+            formatExample(first) == formatExample(second):
+            // Unifying counterexample, the same symbols are derived in two different ways
+            fmt.Fprintf(&builder, "\n    Example: %s\n    %s: %s\n    %s: %s", formatExample(first),
+                labels[0], formatDerivation(first), labels[1], formatDerivation(second))
+        default:
+            fmt.Fprintf(&builder, "\n    First example: %s\n    %s: %s\n    Second example: %s\n    %s: %s",
+                formatExample(first), labels[0], formatDerivation(first), formatExample(second), labels[1], formatDerivation(second))
+        }
+    }
+    fmt.Fprintf(&builder, "\n    Items of state %d:", id)
+    for _, line := range formatItems(state) { fmt.Fprintf(&builder, "\n        %s", line) }
+    return builder.String()
+}
+
+// Finds the shortest prefix that reaches a matching LR(1) item of a state, following the lookaheads of each item.
+// Returns the items that derive each production, from the augmented start production to the matching item.
+func (g *LALRParserGenerator) findShortestDerivation(states []*LRState, state *LRState, match func (item LR1Item) bool) []LR0Item {
+    // Search node struct. Holds an LR(1) item of a state.
+    type node struct { state *LRState; item LR1Item }
+    previous := make(map[node]node)
+    layer := make([]node, 0, len(g.augmented))
+    for i, production := range g.augmented {
+        n := node { states[i], LR1Item { production, 0, EOF_TERMINAL } }
+        previous[n] = n; layer = append(layer, n)
+    }
+    // Symbols are only consumed between layers, so the first matching item found has the shortest prefix
+    for len(layer) > 0 {
+        next := make([]node, 0)
+        for i := 0; i < len(layer); i++ {
+            n := layer[i]
+            if n.state == state && match(n.item) {
+                // Items with the dot at the start were derived from the previous item, so the path is followed backwards
+                path := []LR0Item { { n.item.Production, n.item.Dot } }
+                for ; previous[n] != n; n = previous[n] {
+                    if n.item.Dot == 0 { p := previous[n].item; path = append(path, LR0Item { p.Production, p.Dot }) }
+                }
+                slices.Reverse(path)
+                return path
+            }
+            right := n.item.Production.Right
+            if n.item.Dot >= len(right) { continue }
+            // Advance item to the next state by consuming its next symbol
+            m := node { n.state.Transitions[right[n.item.Dot]], LR1Item { n.item.Production, n.item.Dot + 1, n.item.Lookahead } }
+            if _, ok := previous[m]; !ok { previous[m] = n; next = append(next, m) }
+            // Derive productions of the next non-terminal within the same state
+            t, ok := right[n.item.Dot].(NonTerminal); if !ok { continue }
+            first := g.findSequenceFirst(right[n.item.Dot + 1:])
+            if _, ok := first[EPSILON]; ok { delete(first, EPSILON); first[n.item.Lookahead] = struct{}{} }
+            for _, production := range g.rules[t] {
+                for _, lookahead := range slices.Sorted(maps.Keys(first)) {
+                    m := node { n.state, LR1Item { production, 0, lookahead } }
+                    if _, ok := previous[m]; !ok { previous[m] = n; layer = append(layer, m) }
+                }
+            }
+        }
+        layer = next
+    }
+    return nil
+}
+
+// Searches for a derivation that consumes exactly the given prefix and ends in a matching item.
+// Derivations followed by the given suffix are preferred, otherwise the first matching derivation is returned.
+// Returns nil if no matching derivation is found.
+func (g *LALRParserGenerator) findPrefixDerivation(start *Production, prefix []Symbol, suffix []Symbol,
+    match func (item LR0Item, suffix []Symbol) bool) []LR0Item {
+    // Limit the number of derivations explored, as the number of derivations may grow exponentially
+    const SEARCH_LIMIT int = 100000
+    // Search key struct. Prevents deriving the same item twice without consuming a symbol.
+    type key struct { item LR0Item; position int }
+    var found []LR0Item
+    active, steps := make(map[key]struct{}), 0
+    var search func (path []LR0Item, position int) bool
+    search = func (path []LR0Item, position int) bool {
+        if steps++; steps > SEARCH_LIMIT { return true }
+        item := path[len(path) - 1]
+        if position == len(prefix) {
+            rest := getDerivationSuffix(path)
+            if match(item, rest) {
+                if found == nil { found = slices.Clone(path) }
+                if slices.Equal(rest, suffix) { found = slices.Clone(path); return true }
+            }
+        }
+        right := item.Production.Right
+        if item.Dot >= len(right) { return false }
+        // Advance item if its next symbol is the next symbol of the prefix
+        if position < len(prefix) && right[item.Dot] == prefix[position] {
+            path[len(path) - 1] = LR0Item { item.Production, item.Dot + 1 }
+            done := search(path, position + 1)
+            path[len(path) - 1] = item
+            if done { return true }
+        }
+        // Derive productions of the next non-terminal
+        t, ok := right[item.Dot].(NonTerminal); if !ok { return false }
+        for _, production := range g.rules[t] {
+            k := key { LR0Item { production, 0 }, position }
+            if _, ok := active[k]; ok { continue }
+            active[k] = struct{}{}
+            done := search(append(path, k.item), position)
+            delete(active, k)
+            if done { return true }
+        }
+        return false
+    }
+    search([]LR0Item { { start, 0 } }, 0)
+    return found
+}
+
+// Returns if a token may follow a derivation with the given suffix, the end of the input follows nullable suffixes.
+func (g *LALRParserGenerator) isFollowedBy(suffix []Symbol, t Terminal) bool {
+    first := g.findSequenceFirst(suffix)
+    if _, ok := first[EPSILON]; ok { first[EOF_TERMINAL] = struct{}{} }
+    _, ok := first[t]
+    return ok
+}
+
+// Returns the symbols that follow the dot of a derivation, from the innermost production to the outermost.
+func getDerivationSuffix(path []LR0Item) []Symbol {
+    last := path[len(path) - 1]
+    suffix := slices.Clone(last.Production.Right[last.Dot:])
+    for i := len(path) - 2; i >= 0; i-- { suffix = append(suffix, path[i].Production.Right[path[i].Dot + 1:]...) }
+    return suffix
+}
+
+// Formats the symbols of a derivation, with a dot marking the end of the prefix.
+func formatExample(path []LR0Item) string {
+    symbols := make([]string, 0)
+    for _, item := range path {
+        for _, s := range item.Production.Right[:item.Dot] { symbols = append(symbols, s.String()) }
+    }
+    symbols = append(symbols, "•")
+    for _, s := range getDerivationSuffix(path) { symbols = append(symbols, s.String()) }
+    return strings.Join(symbols, " ")
+}
+
+// Formats the productions of a derivation, the derivation of each non-terminal is enclosed in brackets.
+func formatDerivation(path []LR0Item) string {
+    text := ""
+    for i := len(path) - 1; i > 0; i-- {
+        item := path[i]
+        right := item.Production.Right
+        symbols := []string { fmt.Sprintf("%s ->", item.Production.Left) }
+        for _, s := range right[:item.Dot] { symbols = append(symbols, s.String()) }
+        rest := right[item.Dot:]
+        if i == len(path) - 1 {
+            symbols = append(symbols, "•")
+        } else {
+            symbols = append(symbols, "[" + text + "]")
+            rest = right[item.Dot + 1:]
+        }
+        for _, s := range rest { symbols = append(symbols, s.String()) }
+        text = strings.Join(symbols, " ")
+    }
+    return text
+}
+
+// Formats the LR(1) items of a state, items with the same LR(0) core are listed with all of their lookaheads.
+func formatItems(state *LRState) []string {
+    lookaheads := make(map[LR0Item][]string)
+    for item := range state.Items {
+        core := LR0Item { item.Production, item.Dot }
+        lookaheads[core] = append(lookaheads[core], item.Lookahead.String())
+    }
+    lines := make([]string, 0, len(lookaheads))
+    for core, l := range lookaheads {
+        slices.Sort(l)
+        right := core.Production.Right
+        symbols := make([]string, 0, len(right) + 1)
+        for _, s := range right[:core.Dot] { symbols = append(symbols, s.String()) }
+        symbols = append(symbols, "•")
+        for _, s := range right[core.Dot:] { symbols = append(symbols, s.String()) }
+        lines = append(lines, fmt.Sprintf("[%s -> %s, %s]", core.Production.Left, strings.Join(symbols, " "), strings.Join(l, " ")))
+    }
+    slices.Sort(lines)
+    return lines
+}
+
 // Construct LALR(1) parse table.
 func (g *LALRParserGenerator) buildParseTable(states []*LRState) LRParseTable {
     // Create map from state and production structs to their respective integer identifiers
@@ -237,11 +738,16 @@ func (g *LALRParserGenerator) buildParseTable(states []*LRState) LRParseTable {
     for i, state := range states { stateId[state] = i }
     for i, p := range g.grammar.Productions { productionId[p] = i }
     // Initialize action and goto tables in parse table
+    starts := make([]NonTerminal, len(g.augmented))
+    for i, p := range g.augmented { starts[i] = p.Right[0].(NonTerminal) }
     table := LRParseTable {
-        g.grammar,
+        g.grammar, starts,
         make([]map[Terminal]ActionEntry, len(states)),
         make([]map[NonTerminal]int, len(states)),
+        nil,
+        make([]map[Terminal]string, len(states)),
     }
+    if g.glr { table.Conflicts = make([]map[Terminal][]ActionEntry, len(states)) }
     for i, state := range states {
         action, jump := make(map[Terminal]ActionEntry), make(map[NonTerminal]int)
         table.Action[i], table.Goto[i] = action, jump
@@ -254,24 +760,72 @@ func (g *LALRParserGenerator) buildParseTable(states []*LRState) LRParseTable {
             case NonTerminal: jump[t] = id
             }
         }
+        // Tokens on which non-associative conflicts were resolved to an error, mapped to the production that was not reduced
+        errors, conflicts := make(map[Terminal]int), make(map[Terminal][]ActionEntry)
+        if g.glr { table.Conflicts[i] = conflicts }
+        table.Errors[i] = make(map[Terminal]string)
+        // Identify all LR(1) items of the state where all symbols have been consumed
+        // Items are sorted by production and lookahead so conflicts are resolved and reported in a fixed order
+        complete := make([]LR1Item, 0)
         for item := range state.Items {
-            // Identify all LR(1) items of the state where all symbols have been consumed
-            if item.Dot < len(item.Production.Right) { continue }
-            if item.Production == g.augmented {
+            if item.Dot == len(item.Production.Right) { complete = append(complete, item) }
+        }
+        slices.SortFunc(complete, func (a, b LR1Item) int {
+            if a.Production != b.Production { return productionId[a.Production] - productionId[b.Production] }
+            return strings.Compare(string(a.Lookahead), string(b.Lookahead))
+        })
+        for _, item := range complete {
+            if slices.Contains(g.augmented, item.Production) {
                 // Register an accept action if the production being reduced is the augmented start non-terminal
                 action[EOF_TERMINAL] = ActionEntry{ Type: ACCEPT }
             } else {
                 id := productionId[item.Production]
+                if existing, ok := errors[item.Lookahead]; ok {
+                    other := g.grammar.Productions[existing]
+                    Error(fmt.Sprintf("Reduce/reduce conflict on token %s between productions %v and %v%s%s",
+                        item.Lookahead, item.Production, other, g.describeConflict(states, state, item.Lookahead, item.Production, other),
+                        g.explainConflict(states, i, item.Lookahead, item.Production, other)))
+                    continue
+                }
+                level, ok := g.grammar.ProductionPrecedence[item.Production]
+                if _, exists := action[item.Lookahead]; ok && !exists && level.Associativity == NON_ASSOC &&
+                    slices.Contains(g.operators[item.Lookahead], level.Order) {
+                    // Operations of a non-associative level are not reduced if followed by an operator of the same level
+                    errors[item.Lookahead], table.Errors[i][item.Lookahead] = id, level.Name
+                    continue
+                }
                 if existing, ok := action[item.Lookahead]; ok {
                     switch existing.Type {
                     case SHIFT:
-                        // Reduce action is ignored, preferring shift action if it already exists
-                        Error(fmt.Sprintf("Shift/reduce conflict on token %s for production %v", item.Lookahead, item.Production))
-                        continue
+                        // Resolve conflict using precedence of the token and production if both are declared
+                        switch g.resolveConflict(item.Lookahead, item.Production) {
+                        case RESOLVE_SHIFT: continue
+                        case RESOLVE_REDUCE:
+                        case RESOLVE_ERROR:
+                            // Neither action is taken for non-associative precedence levels, so the token causes a syntax error
+                            delete(action, item.Lookahead)
+                            errors[item.Lookahead], table.Errors[i][item.Lookahead] = id, level.Name
+                            continue
+                        default:
+                            // Reduce action is ignored, preferring shift action if it already exists
+                            // For GLR parsing, the reduce action is kept as a conflicting action
+                            if g.glr { conflicts[item.Lookahead] = append(conflicts[item.Lookahead], ActionEntry { REDUCE, id }); continue }
+                            Error(fmt.Sprintf("Shift/reduce conflict on token %s for production %v%s", item.Lookahead, item.Production,
+                                g.explainConflict(states, i, item.Lookahead, item.Production, nil)))
+                            continue
+                        }
                     case REDUCE:
                         // Resolve reduce/reduce conflict by choosing reduce action with lower production identifier
-                        Error(fmt.Sprintf("Reduce/reduce conflict on token %s between productions %v and %v",
-                            item.Lookahead, item.Production, g.grammar.Productions[existing.Value]))
+                        // For GLR parsing, the other reduce action is kept as a conflicting action
+                        if g.glr {
+                            conflicts[item.Lookahead] = append(conflicts[item.Lookahead], ActionEntry { REDUCE, max(id, existing.Value) })
+                            if id > existing.Value { continue }
+                            break
+                        }
+                        other := g.grammar.Productions[existing.Value]
+                        Error(fmt.Sprintf("Reduce/reduce conflict on token %s between productions %v and %v%s%s",
+                            item.Lookahead, item.Production, other, g.describeConflict(states, state, item.Lookahead, item.Production, other),
+                            g.explainConflict(states, i, item.Lookahead, item.Production, other)))
                         if id > existing.Value { continue }
                     }
                 }
@@ -282,8 +836,33 @@ func (g *LALRParserGenerator) buildParseTable(states []*LRState) LRParseTable {
     return table
 }
 
+// Resolves a shift/reduce conflict between a lookahead token and a production based on their precedence levels.
+// Conflicts are unresolved if either precedence level is not declared, or if equal levels have no associativity.
+func (g *LALRParserGenerator) resolveConflict(t Terminal, p *Production) ResolutionType {
+    token, ok := g.grammar.TerminalPrecedence[t]; if !ok { return UNRESOLVED }
+    production, ok := g.grammar.ProductionPrecedence[p]; if !ok { return UNRESOLVED }
+    switch {
+    case production.Order > token.Order: return RESOLVE_REDUCE
+    case production.Order < token.Order: return RESOLVE_SHIFT
+    }
+    // Equal precedence levels are resolved based on associativity
+    switch token.Associativity {
+    case LEFT_ASSOC:  return RESOLVE_REDUCE
+    case RIGHT_ASSOC: return RESOLVE_SHIFT
+    case NON_ASSOC:   return RESOLVE_ERROR
+    }
+    return UNRESOLVED
+}
+
 // ------------------------------------------------------------------------------------------------------------------------------
 
+// Creates unique identifier string given the LR(0) core of an LR(1) state for use in a map.
+func getCoreKey(state *LRState) string {
+    core := make(map[LR0Item]struct{})
+    for item := range state.Items { core[LR0Item { item.Production, item.Dot }] = struct{}{} }
+    return getLR0ItemStateKey(core)
+}
+
 // Creates unique identifier string given a set of LR(0) items for use in a map.
 func getLR0ItemStateKey(items map[LR0Item]struct{}) string {
     // Sort states by address to ensure identical sets map to the same key
@@ -0,0 +1,166 @@
+package lynn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Returns a grammar definition with the given number of binary operator precedence levels, each with several operators.
+func syntheticGrammar(levels, operators int) string {
+    var builder strings.Builder
+    builder.WriteString("rule prog : stmt* ;\n")
+    builder.WriteString("rule stmt : \"if\" \"(\" e0 \")\" stmt \"else\" stmt | \"while\" \"(\" e0 \")\" stmt | \"{\" stmt* \"}\" | e0 \";\"" +
+        " | \"let\" ID \"=\" e0 \";\" | \"return\" e0? \";\" ;\n")
+    for i := range levels {
+        alternatives := make([]string, 0, operators + 1)
+        for j := range operators { alternatives = append(alternatives, fmt.Sprintf("e%d \"o%d_%d\" e%d", i, i, j, i + 1)) }
+        alternatives = append(alternatives, fmt.Sprintf("e%d", i + 1))
+        fmt.Fprintf(&builder, "rule e%d : %s ;\n", i, strings.Join(alternatives, " | "))
+    }
+    fmt.Fprintf(&builder, "rule e%d : ID | NUM | \"(\" e0 \")\" | ID \"(\" (e0 (\",\" e0)*)? \")\" | \"-\" e%d ;\n", levels, levels)
+    for i := range levels {
+        for j := range operators { fmt.Fprintf(&builder, "token O%d_%d : \"o%d_%d\" ;\n", i, j, i, j) }
+    }
+    for i, keyword := range []string { "if", "else", "while", "let", "return" } {
+        fmt.Fprintf(&builder, "token K%d : \"%s\" ;\n", i, keyword)
+    }
+    for i, symbol := range []string { "(", ")", "{", "}", ";", "=", ",", "-" } {
+        fmt.Fprintf(&builder, "token P%d : \"%s\" ;\n", i, symbol)
+    }
+    builder.WriteString("token ID : [a-z]+ ;\ntoken NUM : [0-9]+ ;\ntoken WS : [ \\t\\n]+ -> skip ;\n")
+    return builder.String()
+}
+
+// Returns a parser generator initialized with a synthetic grammar.
+func syntheticGenerator(tb testing.TB, levels, operators int) *LALRParserGenerator {
+    tb.Helper()
+    path := filepath.Join(tb.TempDir(), "synthetic.ln")
+    if err := os.WriteFile(path, []byte(syntheticGrammar(levels, operators)), 0644); err != nil { tb.Fatal(err) }
+    ast := NewGrammarLoader().Load(path)
+    if Panic() { tb.Fatal("failed to load synthetic grammar") }
+    grammar, _ := NewGrammarGenerator().GenerateCFG(ast)
+    if Panic() { tb.Fatal("failed to generate synthetic grammar") }
+    g := NewLALRParserGenerator(LALR_TABLE, false)
+    g.initialize(grammar)
+    return g
+}
+
+func TestLALRStatesMatchMergedLR1States(t *testing.T) {
+    for _, levels := range []int { 1, 4 } {
+        t.Run(fmt.Sprintf("levels=%d", levels), func (t *testing.T) {
+            g := syntheticGenerator(t, levels, 3)
+            lr1 := g.buildLR1States()
+            mergedStates, propagatedStates := mergeStates(lr1, findCores(lr1)), g.buildLALRStates()
+            if len(mergedStates) != len(propagatedStates) {
+                t.Fatalf("merged LR(1) automaton has %d states, propagated automaton has %d", len(mergedStates), len(propagatedStates))
+            }
+            // Number the merged states like the propagated states with the same LR(0) core
+            cores := make(map[string]int, len(propagatedStates))
+            for i, state := range propagatedStates { cores[getCoreKey(state)] = i }
+            numbering := make([]int, len(mergedStates))
+            for i, state := range mergedStates {
+                j, ok := cores[getCoreKey(state)]
+                if !ok { t.Fatalf("merged state %d has no propagated state with the same core", i) }
+                numbering[i] = j
+            }
+            merged, propagated := g.buildParseTable(mergedStates), g.buildParseTable(propagatedStates)
+            if Panic() { t.Fatal("failed to generate parse tables") }
+            // Tables are identical up to state numbering if every renumbered row of the merged table matches
+            for i := range merged.Action {
+                j := numbering[i]
+                action := make(map[Terminal]ActionEntry, len(merged.Action[i]))
+                for k, a := range merged.Action[i] {
+                    if a.Type == SHIFT { a.Value = numbering[a.Value] }
+                    action[k] = a
+                }
+                next := make(map[NonTerminal]int, len(merged.Goto[i]))
+                for k, n := range merged.Goto[i] { next[k] = numbering[n] }
+                if !reflect.DeepEqual(action, propagated.Action[j]) { t.Errorf("actions of state %d differ", j) }
+                if !reflect.DeepEqual(next, propagated.Goto[j]) { t.Errorf("gotos of state %d differ", j) }
+            }
+        })
+    }
+}
+
+// Runs a parse table on a sequence of tokens.
+// Returns the index of the first token without a valid action or -1 if accepted, and the state in which parsing stopped.
+func simulateTable(table LRParseTable, tokens []Terminal) (int, int) {
+    stack := []int { 0 }
+    tokens = append(tokens, EOF_TERMINAL)
+    for i := 0; ; {
+        state := stack[len(stack) - 1]
+        action, ok := table.Action[state][tokens[i]]
+        if !ok { return i, state }
+        switch action.Type {
+        case SHIFT: stack = append(stack, action.Value); i++
+        case REDUCE:
+            p := table.Grammar.Productions[action.Value]
+            stack = stack[:len(stack) - len(p.Right)]
+            stack = append(stack, table.Goto[stack[len(stack) - 1]][p.Left])
+        case ACCEPT: return -1, state
+        }
+    }
+}
+
+// Chained non-associative operations are rejected at the second operator, which is described by the precedence level in every mode.
+func TestNonAssociativeChainRejected(t *testing.T) {
+    cases := []struct { tokens []Terminal; expected int } {
+        { []Terminal { "X", "LT", "X" }, -1 },
+        { []Terminal { "X", "LT", "X", "PLUS", "X" }, -1 },
+        { []Terminal { "X", "PLUS", "X", "LT", "X", "PLUS", "X" }, -1 },
+        { []Terminal { "X", "LT", "X", "LT", "X" }, 3 },
+        { []Terminal { "X", "LT", "X", "PLUS", "X", "LT", "X" }, 5 },
+    }
+    for _, mode := range []TableMode { LALR_TABLE, LR1_TABLE, MINIMAL_LR1_TABLE } {
+        ast := NewGrammarLoader().Load("testdata/nonassoc.ln")
+        if Panic() { t.Fatal("failed to load grammar") }
+        grammar, _ := NewGrammarGenerator().GenerateCFG(ast)
+        if Panic() { t.Fatal("failed to generate grammar") }
+        table := NewLALRParserGenerator(mode, false).Generate(grammar)
+        if Panic() { t.Fatal("failed to generate parse table") }
+        for _, c := range cases {
+            i, state := simulateTable(table, c.tokens)
+            if i != c.expected { t.Errorf("mode %d, %v: expected %d, got %d", mode, c.tokens, c.expected, i); continue }
+            if i >= 0 && table.Errors[state][c.tokens[i]] != "cmp" {
+                t.Errorf("mode %d, %v: rejected token is not described by precedence level cmp", mode, c.tokens)
+            }
+        }
+    }
+}
+
+// Minimal LR(1) tables keep apart the states whose merging introduces the reduce/reduce conflicts of LALR(1) tables.
+func TestMinimalTableAvoidsMergeConflicts(t *testing.T) {
+    tables := make(map[TableMode]LRParseTable)
+    for _, mode := range []TableMode { LALR_TABLE, LR1_TABLE, MINIMAL_LR1_TABLE } {
+        ast := NewGrammarLoader().Load("testdata/merge.ln")
+        if Panic() { t.Fatal("failed to load grammar") }
+        grammar, _ := NewGrammarGenerator().GenerateCFG(ast)
+        if Panic() { t.Fatal("failed to generate grammar") }
+        tables[mode] = NewLALRParserGenerator(mode, false).Generate(grammar)
+        if expected, failed := mode == LALR_TABLE, Panic(); failed != expected {
+            t.Errorf("mode %d: expected conflicts %t, got %t", mode, expected, failed)
+        }
+        occurred = false
+    }
+    lalr, lr1, minimal := len(tables[LALR_TABLE].Action), len(tables[LR1_TABLE].Action), len(tables[MINIMAL_LR1_TABLE].Action)
+    if minimal <= lalr || minimal >= lr1 {
+        t.Errorf("expected minimal table to have between %d and %d states, got %d", lalr, lr1, minimal)
+    }
+}
+
+func BenchmarkLR1StatesMerged(b *testing.B) {
+    g := syntheticGenerator(b, 8, 6)
+    for b.Loop() {
+        states := g.buildLR1States()
+        mergeStates(states, findCores(states))
+    }
+}
+
+func BenchmarkLALRStatesPropagated(b *testing.B) {
+    g := syntheticGenerator(b, 8, 6)
+    for b.Loop() { g.buildLALRStates() }
+}